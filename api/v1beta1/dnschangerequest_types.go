@@ -0,0 +1,143 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=dnschangerequests,scope=Namespaced,singular=dnschangerequest
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ExternalDNS",type=string,JSONPath=`.spec.externalDNSName`
+// +kubebuilder:printcolumn:name="Approved",type=boolean,JSONPath=`.spec.approved`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DNSChangeRequest is the plan of the changes of the DNS records
+// computed by an ExternalDNS instance which requires the manual approval
+// of the changes. The operator creates a request for each new plan
+// in the operand namespace and applies the plan once the request is approved.
+type DNSChangeRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the plan of the changes and its approval.
+	Spec DNSChangeRequestSpec `json:"spec"`
+	// status is the most recently observed progress of the request.
+	Status DNSChangeRequestStatus `json:"status,omitempty"`
+}
+
+// DNSChangeRequestSpec defines the changes planned by ExternalDNS
+// and whether they are approved.
+type DNSChangeRequestSpec struct {
+	// ExternalDNSName is the name of the ExternalDNS instance
+	// which planned the changes.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	ExternalDNSName string `json:"externalDNSName"`
+
+	// Changes are the changes of the DNS records planned by ExternalDNS.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Changes DNSChanges `json:"changes"`
+
+	// Approved allows the operator to apply the changes.
+	// The changes are applied only if ExternalDNS still plans
+	// the exact same changes at the moment of the approval,
+	// otherwise the request is marked as drifted.
+	// The application is limited to the approved records and their parent domains.
+	// The approval covers the names and types of the records, not their values.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Approved bool `json:"approved,omitempty"`
+}
+
+// DNSChanges lists the DNS records planned to be changed.
+// The records are identified by their name and type, e.g. "app.example.com A".
+type DNSChanges struct {
+	// Creates are the DNS records planned to be created.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Creates []string `json:"creates,omitempty"`
+
+	// Updates are the DNS records planned to be updated.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Updates []string `json:"updates,omitempty"`
+
+	// Deletes are the DNS records planned to be deleted.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Deletes []string `json:"deletes,omitempty"`
+}
+
+// DNSChangeRequestStatus defines the observed progress of DNSChangeRequest.
+type DNSChangeRequestStatus struct {
+	// Phase is the progress of the request.
+	Phase DNSChangeRequestPhase `json:"phase,omitempty"`
+
+	// Message is a human readable description of the phase.
+	Message string `json:"message,omitempty"`
+
+	// JobName is the name of the job which plans the approved changes again
+	// with a dry run, or of the job which applies them once the plan matched.
+	JobName string `json:"jobName,omitempty"`
+
+	// CompletionTime is the time the application of the changes finished.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+type DNSChangeRequestPhase string
+
+const (
+	// DNSChangeRequestPhasePending means that the request waits for the approval.
+	DNSChangeRequestPhasePending DNSChangeRequestPhase = "Pending"
+	// DNSChangeRequestPhaseApplying means that the approved changes are being planned again
+	// and applied if the plan still matches.
+	DNSChangeRequestPhaseApplying DNSChangeRequestPhase = "Applying"
+	// DNSChangeRequestPhaseApplied means that the approved changes were applied.
+	DNSChangeRequestPhaseApplied DNSChangeRequestPhase = "Applied"
+	// DNSChangeRequestPhaseFailed means that the application of the approved changes failed,
+	// that the planned or applied changes could not be recognized in the logs of the jobs,
+	// or that different changes than the approved ones were logged by the apply job.
+	DNSChangeRequestPhaseFailed DNSChangeRequestPhase = "Failed"
+	// DNSChangeRequestPhaseDrifted means that ExternalDNS planned different changes
+	// before the request was applied, including the plan made right before the application,
+	// the request is superseded by a new one.
+	DNSChangeRequestPhaseDrifted DNSChangeRequestPhase = "Drifted"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//
+// DNSChangeRequestList contains a list of DNSChangeRequests.
+type DNSChangeRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSChangeRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSChangeRequest{}, &DNSChangeRequestList{})
+}
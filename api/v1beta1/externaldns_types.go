@@ -66,12 +66,24 @@ type ExternalDNSSpec struct {
 
 	// Provider refers to the DNS provider that ExternalDNS
 	// should publish records to. Note that each ExternalDNS
-	// is tied to a single provider.
+	// is tied to a single provider unless AdditionalProviders are given.
 	//
 	// +kubebuilder:validation:Required
 	// +required
 	Provider ExternalDNSProvider `json:"provider"`
 
+	// AdditionalProviders refers to the DNS providers
+	// to which the same records are mirrored, in addition to Provider.
+	// All the providers share the ownership ID of this ExternalDNS.
+	// Each provider type can be specified only once,
+	// including the type of Provider.
+	// The credentials must be given explicitly for each additional provider.
+	//
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:Optional
+	// +optional
+	AdditionalProviders []ExternalDNSProviderTarget `json:"additionalProviders,omitempty"`
+
 	// Source describes which source resource
 	// ExternalDNS will be configured to create
 	// DNS records for.
@@ -97,10 +109,608 @@ type ExternalDNSSpec struct {
 	// lookup a default set of zones e.g on OpenShift with its cluster
 	// DNS config
 	//
+	// A GCP zone can be qualified with the project it belongs to
+	// using the "projects/<project>/managedZones/<zone>" form.
+	// An Azure zone given as the resource ID is published to
+	// using the subscription and the resource group from the ID.
+	// This allows a single ExternalDNS to publish to the zones
+	// which belong to different GCP projects or Azure subscriptions.
+	//
+	// The InMemory provider requires the zones to be specified,
+	// they are created empty in the memory of ExternalDNS.
+	//
 	// +kubebuilder:validation:MaxItems=10
 	// +kubebuilder:validation:Optional
 	// +optional
 	Zones []string `json:"zones,omitempty"`
+
+	// Policy defines how ExternalDNS synchronizes the DNS records
+	// with the desired state computed from the sources.
+	//
+	// The following values are accepted:
+	//
+	//  "Sync": Create, update and delete the records.
+	//  "UpsertOnly": Create and update the records, never delete them.
+	//  "CreateOnly": Only create the records, never update nor delete them.
+	//
+	// The default behavior of the ExternalDNS is "Sync".
+	//
+	// Note that with "Sync" the records are deleted
+	// once the source resources are removed.
+	//
+	// +kubebuilder:default:=Sync
+	// +kubebuilder:validation:Optional
+	// +optional
+	Policy ExternalDNSPolicy `json:"policy,omitempty"`
+
+	// DryRun instructs ExternalDNS to compute the changes
+	// of the DNS records without applying them.
+	//
+	// The planned changes are collected from the logs of ExternalDNS
+	// into a configmap in the operand namespace and summarized in the status.
+	// This allows to review the changes before the dry run is turned off.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// ChangeApproval defines whether the changes of the DNS records
+	// have to be approved before being applied.
+	//
+	// The following values are accepted:
+	//
+	//  "None": The changes are applied by ExternalDNS right away.
+	//  "Manual": ExternalDNS only plans the changes, the operator writes
+	//            each new plan into a DNSChangeRequest in the operand namespace
+	//            and applies it with a one-shot job once the request is approved.
+	//
+	// The default behavior of the ExternalDNS is "None".
+	// The manual approval cannot be combined with the dry run.
+	//
+	// +kubebuilder:default:=None
+	// +kubebuilder:validation:Optional
+	// +optional
+	ChangeApproval ExternalDNSChangeApproval `json:"changeApproval,omitempty"`
+
+	// Operand describes the ExternalDNS image run by the instance.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Operand *ExternalDNSOperand `json:"operand,omitempty"`
+
+	// Logging describes the verbosity and the format of the ExternalDNS logs.
+	// A single zone can be switched to the debug level temporarily
+	// with the "externaldns.olm.openshift.io/debug-zone" and
+	// "externaldns.olm.openshift.io/debug-zone-expiry" annotations.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Logging *ExternalDNSLogging `json:"logging,omitempty"`
+
+	// Metrics describes how the metrics of the ExternalDNS containers are exposed.
+	// A Service selecting the pods of each ExternalDNS deployment is created
+	// with a port for the kube-rbac-proxy sidecar of each container,
+	// serving the metrics over TLS to the authorized clients.
+	// The metrics are not exposed outside of the pods if omitted.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Metrics *ExternalDNSMetrics `json:"metrics,omitempty"`
+
+	// OperandMetadata describes the custom labels and annotations
+	// set on the resources created for the instance in the operand namespace:
+	// the ExternalDNS deployment, its pods, the service account and the copied secrets.
+	// The labels and annotations removed from the spec are removed from the resources.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	OperandMetadata *ExternalDNSOperandMetadata `json:"operandMetadata,omitempty"`
+
+	// OperandDeployment describes the compute resources
+	// and the scheduling of the ExternalDNS deployment.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	OperandDeployment *ExternalDNSOperandDeployment `json:"operandDeployment,omitempty"`
+
+	// UnsupportedOperandOverrides passes extra command line flags
+	// and environment variables to the ExternalDNS containers as they are.
+	// It allows to use the ExternalDNS features not yet supported by the operator API.
+	// The flags and the environment variables managed by the operator are rejected.
+	// The instance using the overrides is marked with the UnsupportedOperandOverrides condition
+	// and is not supported.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	UnsupportedOperandOverrides *ExternalDNSUnsupportedOperandOverrides `json:"unsupportedOperandOverrides,omitempty"`
+
+	// DeploymentTopology defines how the ExternalDNS containers
+	// of the zones are distributed among the deployments.
+	//
+	// The following values are accepted:
+	//
+	//  "SingleDeployment": All the zones are served by the containers of a single pod.
+	//                      A change of any zone restarts all the containers.
+	//  "DeploymentPerZone": Each zone is served by its own deployment
+	//                       with its own metrics port, lifecycle and status conditions.
+	//                       The deployments of the zones removed from the spec are deleted.
+	//
+	// The default behavior of the ExternalDNS is "SingleDeployment".
+	//
+	// +kubebuilder:default:=SingleDeployment
+	// +kubebuilder:validation:Optional
+	// +optional
+	DeploymentTopology ExternalDNSDeploymentTopology `json:"deploymentTopology,omitempty"`
+
+	// Paused freezes the instance without deleting it,
+	// e.g. during the incidents of the DNS provider or the migrations of the zones.
+	// The ExternalDNS deployment is scaled down to zero replicas,
+	// the credentials request is not updated and the Paused condition is set.
+	// The instance resumes with the current spec once the flag is cleared.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// DeletionPolicy defines what happens to the DNS records
+	// when the ExternalDNS instance is deleted.
+	//
+	// The following values are accepted:
+	//
+	//  "Orphan": The DNS records are left in the zones.
+	//  "Delete": The deletion of the instance is held by a finalizer
+	//            until the DNS records owned by the instance are deleted
+	//            by a one-shot ExternalDNS job without any source.
+	//
+	// The default behavior of the ExternalDNS is "Orphan".
+	//
+	// +kubebuilder:default:=Orphan
+	// +kubebuilder:validation:Optional
+	// +optional
+	DeletionPolicy ExternalDNSDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DeletionGuard limits the number of the DNS records
+	// ExternalDNS is allowed to delete in a synchronization.
+	//
+	// The ExternalDNS deployments run with the "UpsertOnly" policy,
+	// the deletions are planned by a one-shot dry run of the ExternalDNS containers
+	// and applied by a one-shot run of the containers whose plan is within the limit.
+	// A container which exceeds the limit doesn't delete the records,
+	// the ChangesBlocked condition is set and a warning event is emitted
+	// until the deletions are acknowledged with the
+	// "externaldns.olm.openshift.io/acknowledge-blocked-changes" annotation.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DeletionGuard *ExternalDNSDeletionGuard `json:"deletionGuard,omitempty"`
+
+	// Sync describes when ExternalDNS synchronizes the DNS records
+	// and the TTL of the records it creates.
+	// The defaults of ExternalDNS are used for the omitted settings.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Sync *ExternalDNSSyncOptions `json:"sync,omitempty"`
+
+	// Registry describes how ExternalDNS keeps track
+	// of the ownership of the DNS records it manages.
+	//
+	// Changing the ownership ID or the TXT record affixes
+	// after creation will cause the previously created records
+	// to be left behind, such changes are rejected
+	// unless AllowOwnershipChange is set.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Registry *ExternalDNSRegistry `json:"registry,omitempty"`
+}
+
+// ExternalDNSOperand describes the ExternalDNS image and how it's pulled.
+type ExternalDNSOperand struct {
+	// Image is the ExternalDNS image run by the instance
+	// instead of the image the operator is configured with,
+	// e.g. to canary a new ExternalDNS build on a single instance.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImagePullSecrets are the secrets in the operand namespace
+	// used to pull the ExternalDNS image from a private registry.
+	// The secrets are attached to the service account of the instance.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// ExternalDNSMetrics describes the exposure of the ExternalDNS metrics.
+type ExternalDNSMetrics struct {
+	// ServiceMonitor creates a Prometheus ServiceMonitor for each metrics Service,
+	// the scraped series are labelled with the instance and the container of the zone.
+	// The monitoring.coreos.com API of the Prometheus operator must be installed in the cluster.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	ServiceMonitor bool `json:"serviceMonitor,omitempty"`
+
+	// ScrapeInterval is the interval at which Prometheus scrapes the metrics.
+	// The default interval of Prometheus is used if omitted.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	ScrapeInterval *metav1.Duration `json:"scrapeInterval,omitempty"`
+}
+
+// ExternalDNSLogging describes the logs of the ExternalDNS containers.
+type ExternalDNSLogging struct {
+	// Level is the verbosity of the ExternalDNS logs.
+	//
+	// The following values are accepted:
+	//
+	//  "Debug": Logs every record evaluated in each synchronization.
+	//  "Info": Logs the changes of the DNS records.
+	//  "Warning": Logs only the warnings and the errors.
+	//  "Error": Logs only the errors.
+	//
+	// The planned changes used by the dry run, the manual change approval
+	// and the deletion guard are logged at the "Info" level,
+	// the higher levels are rejected for these features.
+	//
+	// The default level is "Debug".
+	//
+	// +kubebuilder:default:=Debug
+	// +kubebuilder:validation:Optional
+	// +optional
+	Level ExternalDNSLogLevel `json:"level,omitempty"`
+
+	// Format is the format of the ExternalDNS logs.
+	//
+	// The following values are accepted:
+	//
+	//  "Text": Logs are written as logfmt lines.
+	//  "JSON": Logs are written as JSON objects.
+	//
+	// The default format is "Text".
+	//
+	// +kubebuilder:default:=Text
+	// +kubebuilder:validation:Optional
+	// +optional
+	Format ExternalDNSLogFormat `json:"format,omitempty"`
+}
+
+// ExternalDNSUnsupportedOperandOverrides describes the extra configuration of the ExternalDNS containers.
+type ExternalDNSUnsupportedOperandOverrides struct {
+	// Args are the extra command line flags of ExternalDNS
+	// appended to the flags set by the operator, e.g. "--aws-batch-change-size=100".
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env are the extra environment variables of the ExternalDNS containers.
+	// The variables set by the operator and the EXTERNAL_DNS_* variables
+	// of the flags managed by the operator are rejected.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// ExternalDNSOperandMetadata describes the custom metadata of the operand resources.
+type ExternalDNSOperandMetadata struct {
+	// Labels are added to the labels of the operand resources.
+	// The "app.kubernetes.io/name", "app.kubernetes.io/instance" labels
+	// and the labels with the "externaldns.olm.openshift.io/" prefix are reserved for the operator.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the annotations of the operand resources.
+	// The annotations with the "externaldns.olm.openshift.io/" prefix are reserved for the operator.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ExternalDNSOperandDeployment describes the resources and the scheduling of the ExternalDNS pods.
+type ExternalDNSOperandDeployment struct {
+	// Resources are the compute resources of each ExternalDNS container.
+	// No resources are requested if omitted.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector replaces the default node selector
+	// which schedules the ExternalDNS pods on the Linux nodes.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations replace the default toleration
+	// which allows the ExternalDNS pods to run on the control plane nodes.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity is the scheduling affinity of the ExternalDNS pods.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describe how the ExternalDNS pods are spread across the topology domains.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the ExternalDNS pods.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Probes describes the timings of the liveness and readiness probes
+	// of the ExternalDNS containers.
+	// The probes query the "/healthz" endpoint on the metrics port of each container.
+	// The defaults of the operator are used for the omitted timings.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Probes *ExternalDNSOperandProbes `json:"probes,omitempty"`
+
+	// WritableTmp mounts a writable emptyDir volume on the /tmp directory
+	// of the ExternalDNS containers for the images which need to write temporary files.
+	// The rest of the root filesystem of the containers stays read-only.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	WritableTmp bool `json:"writableTmp,omitempty"`
+}
+
+// ExternalDNSOperandProbes describes the timings of the probes of the ExternalDNS containers.
+type ExternalDNSOperandProbes struct {
+	// InitialDelaySeconds is the number of seconds after the start of the container
+	// before the probes are initiated.
+	// The default delay is 10 seconds.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds is how often the probes are performed.
+	// The default period is 10 seconds.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds is the number of seconds after which a probe times out.
+	// The default timeout is 5 seconds.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold is the number of the consecutive failures
+	// after which the container is marked as not ready by the readiness probe
+	// and restarted by the liveness probe.
+	// The default threshold is 3.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// ExternalDNSSyncOptions describes the synchronization of the DNS records.
+type ExternalDNSSyncOptions struct {
+	// Interval is the interval between the synchronizations of the DNS records.
+	// It must be between 10 seconds and 24 hours.
+	// The default interval of ExternalDNS is 1 minute.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Events triggers a synchronization each time a source is added, updated or deleted,
+	// in addition to the periodic synchronizations.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Events bool `json:"events,omitempty"`
+
+	// MinEventSyncInterval is the minimum interval between the synchronizations triggered by events.
+	// It must be between 1 second and the synchronization interval.
+	// The default interval of ExternalDNS is 5 seconds.
+	// Can be set only if the events are enabled.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	MinEventSyncInterval *metav1.Duration `json:"minEventSyncInterval,omitempty"`
+
+	// MinTTL is the TTL of the created DNS records whose source doesn't specify one.
+	// It must be a whole number of seconds between 1 second and 24 hours.
+	// The default TTL of the DNS provider is used if omitted.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	MinTTL *metav1.Duration `json:"minTTL,omitempty"`
+}
+
+// ExternalDNSDeletionGuard describes the limit of the deletions of the DNS records.
+type ExternalDNSDeletionGuard struct {
+	// MaxDeletions is the maximum number of the DNS records,
+	// including the records of the TXT registry,
+	// a single ExternalDNS container can delete.
+	// The deletions are counted from the plan of a one-shot dry run.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	// +required
+	MaxDeletions int32 `json:"maxDeletions"`
+}
+
+// ExternalDNSRegistry describes the registry
+// which ExternalDNS uses to mark the owned DNS records.
+type ExternalDNSRegistry struct {
+	// Type is the type of the registry which keeps
+	// the ownership of the DNS records.
+	//
+	// Allowed values are:
+	//
+	// * TXT
+	// * DynamoDB
+	//
+	// The TXT registry creates a TXT record next to each managed DNS record.
+	// The DynamoDB registry keeps the ownership in an AWS DynamoDB table,
+	// it is supported only for AWS provider without additional providers.
+	//
+	// Defaults to TXT if not specified.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Type ExternalDNSRegistryType `json:"type,omitempty"`
+
+	// DynamoDB describes the DynamoDB registry.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DynamoDB *ExternalDNSDynamoDBRegistryOptions `json:"dynamodb,omitempty"`
+
+	// OwnerID is the identifier written into the TXT records
+	// which mark the DNS records owned by this ExternalDNS.
+	// Useful to take over the records created by another
+	// deployment of ExternalDNS.
+	//
+	// Defaults to "external-dns-<name>" if not specified.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	OwnerID string `json:"ownerID,omitempty"`
+
+	// TXTPrefix is the prefix of the names of the TXT ownership records.
+	// Cannot be specified along with TXTSuffix.
+	//
+	// Defaults to "external-dns-" if neither TXTPrefix nor TXTSuffix is specified,
+	// no prefix is used by default for the InMemory provider.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	TXTPrefix string `json:"txtPrefix,omitempty"`
+
+	// TXTSuffix is the suffix of the names of the TXT ownership records.
+	// Cannot be specified along with TXTPrefix.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	TXTSuffix string `json:"txtSuffix,omitempty"`
+
+	// WildcardReplacement is the string which replaces
+	// the asterisk of the wildcard DNS records
+	// in the names of the TXT ownership records.
+	//
+	// Defaults to "any" for Azure provider,
+	// no replacement is used for the other providers.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	WildcardReplacement string `json:"wildcardReplacement,omitempty"`
+
+	// AllowOwnershipChange allows the update of the ownership ID,
+	// the TXT record affixes and the wildcard replacement.
+	// Set it only if the existing records were migrated
+	// or are supposed to be left behind.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	AllowOwnershipChange bool `json:"allowOwnershipChange,omitempty"`
+
+	// MigrateFromOwnerID is the previous owner ID of the DNS records
+	// which have to be taken over by this ExternalDNS,
+	// e.g. after the cluster was rebuilt or the ExternalDNS was renamed.
+	// ExternalDNS runs in the migration mode rewriting the ownership
+	// of the records from the previous owner ID to the current one.
+	// The migration is reported by the OwnerIDMigrated status condition,
+	// ExternalDNS is switched back to the normal synchronization
+	// once the migration is completed.
+	// Supported only for TXT registry.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	MigrateFromOwnerID string `json:"migrateFromOwnerID,omitempty"`
+
+	// Encryption enables the encryption of the TXT ownership records,
+	// this hides the names of the cluster and of the source resources
+	// from the public DNS.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Encryption *ExternalDNSRegistryEncryption `json:"encryption,omitempty"`
+}
+
+// ExternalDNSDynamoDBRegistryOptions describes the DynamoDB table
+// in which ExternalDNS keeps the ownership of the DNS records.
+type ExternalDNSDynamoDBRegistryOptions struct {
+	// Table is the name of the DynamoDB table.
+	// The table has to be created in advance.
+	//
+	// Defaults to "external-dns" if not specified.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]*$`
+	// +optional
+	Table string `json:"table,omitempty"`
+
+	// Region is the AWS region of the DynamoDB table.
+	//
+	// Defaults to the region of the cluster on OpenShift,
+	// to the region of the AWS SDK configuration otherwise.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Region string `json:"region,omitempty"`
+}
+
+// ExternalDNSRegistryType is the type of the registry of the DNS records ownership.
+// +kubebuilder:validation:Enum=TXT;DynamoDB
+type ExternalDNSRegistryType string
+
+const (
+	RegistryTypeTXT      ExternalDNSRegistryType = "TXT"
+	RegistryTypeDynamoDB ExternalDNSRegistryType = "DynamoDB"
+)
+
+// ExternalDNSRegistryEncryption describes the encryption of the TXT ownership records.
+type ExternalDNSRegistryEncryption struct {
+	// Key is a reference to a secret holding the AES key
+	// used to encrypt the TXT ownership records.
+	// The secret must be in the same namespace as the operator
+	// and must contain the 32 bytes long key, raw or base64 encoded,
+	// in the EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY entry.
+	//
+	// Updating the key secret restarts ExternalDNS,
+	// the records encrypted with the previous key
+	// are not recognized as owned anymore.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Key SecretReference `json:"key"`
 }
 
 // ExternalDNSDomain describes how sets of included
@@ -188,6 +798,22 @@ const (
 	FilterTypeExclude ExternalDNSFilterType = "Exclude"
 )
 
+// ExternalDNSProviderTarget specifies an additional DNS provider
+// and the zones to which ExternalDNS publishes records.
+type ExternalDNSProviderTarget struct {
+	ExternalDNSProvider `json:",inline"`
+
+	// Zones describes which DNS Zone IDs of the provider
+	// ExternalDNS should publish records to.
+	// An empty list of zones means that ExternalDNS will
+	// publish to all zones of the provider.
+	//
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:Optional
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+}
+
 // ExternalDNSProvider specifies configuration
 // options for the desired ExternalDNS DNS provider.
 // +union
@@ -201,6 +827,7 @@ type ExternalDNSProvider struct {
 	//  * Azure
 	//  * BlueCat
 	//  * Infoblox
+	//  * InMemory (records are kept in the memory of the operand, no credentials needed)
 	//
 	// +kubebuilder:validation:Required
 	// +unionDiscriminator
@@ -311,6 +938,9 @@ type ExternalDNSAzureProviderOptions struct {
 type ExternalDNSBlueCatProviderOptions struct {
 	// ConfigFile is a reference to a secret containing
 	// the necessary information to use the BlueCat provider.
+	// Deprecated: use the structured configuration fields
+	// (GatewayHost, DNSConfiguration, DNSView, etc.) along with Credentials instead.
+	// ConfigFile cannot be specified together with Credentials.
 	// The secret referenced by ConfigFile should contain
 	// an object named `bluecat.json` similar to the following:
 	//
@@ -328,9 +958,72 @@ type ExternalDNSBlueCatProviderOptions struct {
 	// https://github.com/kubernetes-sigs/external-dns/blob/226dbb931f7a2019810b3703aec096c4ea4f40ea/docs/tutorials/bluecat.md#using-json-configuration-file
 	// for more information on the necessary configuration values and how to obtain them.
 	//
-	// +kubebuilder:validation:Required
-	// +required
-	ConfigFile SecretReference `json:"configFile"`
+	// +kubebuilder:validation:Optional
+	// +optional
+	ConfigFile SecretReference `json:"configFile,omitempty"`
+
+	// Credentials is a reference to a secret containing
+	// the following keys (with corresponding values):
+	//
+	// * gatewayUsername
+	// * gatewayPassword
+	//
+	// The operator generates the BlueCat configuration file
+	// from the credentials and the fields below.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Credentials *SecretReference `json:"credentials,omitempty"`
+
+	// GatewayHost is the URL of the BlueCat Gateway.
+	// Required when Credentials is specified.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	GatewayHost string `json:"gatewayHost,omitempty"`
+
+	// DNSConfiguration is the name of the DNS configuration in BlueCat Address Manager.
+	// Required when Credentials is specified.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DNSConfiguration string `json:"dnsConfiguration,omitempty"`
+
+	// DNSView is the name of the DNS view in BlueCat Address Manager.
+	// Required when Credentials is specified.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DNSView string `json:"dnsView,omitempty"`
+
+	// RootZone is the zone under which ExternalDNS manages the records.
+	// All the zones of the view are used if empty.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	RootZone string `json:"rootZone,omitempty"`
+
+	// DNSServerName is the name of the DNS server
+	// to which the changes are deployed.
+	// Required when DeployOnChange is set.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DNSServerName string `json:"dnsServerName,omitempty"`
+
+	// DeployOnChange instructs BlueCat to quick deploy
+	// the changed records to the DNS server.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DeployOnChange bool `json:"deployOnChange,omitempty"`
+
+	// SkipTLSVerify disables the verification
+	// of the gateway's TLS certificate.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
 }
 
 type ExternalDNSInfobloxProviderOptions struct {
@@ -361,6 +1054,47 @@ type ExternalDNSInfobloxProviderOptions struct {
 	// +kubebuilder:validation:Required
 	// +required
 	WAPIVersion string `json:"wapiVersion"`
+
+	// View is the DNS view in which ExternalDNS manages the records.
+	// The default DNS view of the grid is used if empty.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	View string `json:"view,omitempty"`
+
+	// CreatePTR enables the creation of PTR records
+	// along with A records.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	CreatePTR bool `json:"createPTR,omitempty"`
+
+	// MaxResults is the maximum number of objects
+	// ExternalDNS requests from the WAPI in a single call.
+	// The WAPI default is used if zero.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxResults int `json:"maxResults,omitempty"`
+
+	// SkipTLSVerify disables the verification
+	// of the grid's TLS certificate.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
+
+	// GridCA is a reference to a config map containing
+	// the CA bundle used to verify the grid's TLS certificate,
+	// in addition to the system trusted CAs.
+	// The config map should contain the following key:
+	//
+	// * ca-bundle.crt
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	GridCA *ConfigMapReference `json:"gridCA,omitempty"`
 }
 
 // SecretReference contains the information to let you locate the desired secret.
@@ -373,7 +1107,17 @@ type SecretReference struct {
 	Name string `json:"name"`
 }
 
-// +kubebuilder:validation:Enum=AWS;GCP;Azure;BlueCat;Infoblox
+// ConfigMapReference contains the information to let you locate the desired config map.
+// Config map is required to be in the operator namespace.
+type ConfigMapReference struct {
+	// Name is the name of the config map.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Name string `json:"name"`
+}
+
+// +kubebuilder:validation:Enum=AWS;GCP;Azure;BlueCat;Infoblox;InMemory
 type ExternalDNSProviderType string
 
 const (
@@ -382,6 +1126,7 @@ const (
 	ProviderTypeAzure    ExternalDNSProviderType = "Azure"
 	ProviderTypeBlueCat  ExternalDNSProviderType = "BlueCat"
 	ProviderTypeInfoblox ExternalDNSProviderType = "Infoblox"
+	ProviderTypeInMemory ExternalDNSProviderType = "InMemory"
 	// More providers will ultimately be added in the future.
 )
 
@@ -475,6 +1220,57 @@ const (
 	SourceTypeCRD     ExternalDNSSourceType = "CRD"
 )
 
+// +kubebuilder:validation:Enum=Sync;UpsertOnly;CreateOnly
+type ExternalDNSPolicy string
+
+const (
+	PolicySync       ExternalDNSPolicy = "Sync"
+	PolicyUpsertOnly ExternalDNSPolicy = "UpsertOnly"
+	PolicyCreateOnly ExternalDNSPolicy = "CreateOnly"
+)
+
+// +kubebuilder:validation:Enum=None;Manual
+type ExternalDNSChangeApproval string
+
+const (
+	ChangeApprovalNone   ExternalDNSChangeApproval = "None"
+	ChangeApprovalManual ExternalDNSChangeApproval = "Manual"
+)
+
+// +kubebuilder:validation:Enum=Orphan;Delete
+type ExternalDNSDeletionPolicy string
+
+const (
+	DeletionPolicyOrphan ExternalDNSDeletionPolicy = "Orphan"
+	DeletionPolicyDelete ExternalDNSDeletionPolicy = "Delete"
+)
+
+// +kubebuilder:validation:Enum=SingleDeployment;DeploymentPerZone
+type ExternalDNSDeploymentTopology string
+
+const (
+	DeploymentTopologySingleDeployment  ExternalDNSDeploymentTopology = "SingleDeployment"
+	DeploymentTopologyDeploymentPerZone ExternalDNSDeploymentTopology = "DeploymentPerZone"
+)
+
+// +kubebuilder:validation:Enum=Debug;Info;Warning;Error
+type ExternalDNSLogLevel string
+
+const (
+	LogLevelDebug   ExternalDNSLogLevel = "Debug"
+	LogLevelInfo    ExternalDNSLogLevel = "Info"
+	LogLevelWarning ExternalDNSLogLevel = "Warning"
+	LogLevelError   ExternalDNSLogLevel = "Error"
+)
+
+// +kubebuilder:validation:Enum=Text;JSON
+type ExternalDNSLogFormat string
+
+const (
+	LogFormatText ExternalDNSLogFormat = "Text"
+	LogFormatJSON ExternalDNSLogFormat = "JSON"
+)
+
 // +kubebuilder:validation:Enum=Ignore;Allow
 type HostnameAnnotationPolicy string
 
@@ -585,8 +1381,101 @@ type ExternalDNSStatus struct {
 
 	// Zones is the configured zones in use by ExternalDNS.
 	Zones []string `json:"zones,omitempty"`
+
+	// MigratedFromOwnerID is the previous owner ID
+	// from which the ownership of the DNS records was migrated.
+	MigratedFromOwnerID string `json:"migratedFromOwnerID,omitempty"`
+
+	// DryRun is the summary of the changes of the DNS records
+	// planned by ExternalDNS in the dry run mode.
+	DryRun *ExternalDNSDryRunStatus `json:"dryRun,omitempty"`
+
+	// DeletionGuard is the state of the guard
+	// of the deletions of the DNS records.
+	DeletionGuard *ExternalDNSDeletionGuardStatus `json:"deletionGuard,omitempty"`
+
+	// ZoneDeployments are the statuses of the deployments of the zones
+	// when each zone is served by its own deployment.
+	ZoneDeployments []ExternalDNSZoneDeploymentStatus `json:"zoneDeployments,omitempty"`
+
+	// OperandStarted is true once the ExternalDNS deployments were created.
+	// The records of an instance with the "Delete" deletion policy
+	// are cleaned up on its deletion only if its operand was started,
+	// even if the deployments were already garbage collected.
+	OperandStarted bool `json:"operandStarted,omitempty"`
 }
 
+// ExternalDNSZoneDeploymentStatus describes the deployment of a zone.
+type ExternalDNSZoneDeploymentStatus struct {
+	// Zone is the zone served by the deployment.
+	// Empty if ExternalDNS publishes to all the zones of the provider.
+	Zone string `json:"zone,omitempty"`
+
+	// Provider is the type of the provider the deployment publishes to.
+	Provider ExternalDNSProviderType `json:"provider,omitempty"`
+
+	// DeploymentName is the name of the deployment in the operand namespace.
+	DeploymentName string `json:"deploymentName"`
+
+	// Conditions are the availability conditions of the deployment.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ExternalDNSDeletionGuardStatus describes the containers blocked by the deletion guard.
+type ExternalDNSDeletionGuardStatus struct {
+	// BlockedContainers are the names of the ExternalDNS containers
+	// whose last plan exceeded the maximum number of deletions
+	// and whose deletions were not applied.
+	BlockedContainers []string `json:"blockedContainers,omitempty"`
+
+	// AcknowledgedTime is the time the blocked deletions were last acknowledged.
+	AcknowledgedTime *metav1.Time `json:"acknowledgedTime,omitempty"`
+
+	// PlannedTime is the time the deletions were last planned.
+	PlannedTime *metav1.Time `json:"plannedTime,omitempty"`
+}
+
+// ExternalDNSDryRunStatus summarizes the changes of the DNS records
+// planned by ExternalDNS in the dry run mode.
+type ExternalDNSDryRunStatus struct {
+	// ConfigMapName is the name of the configmap in the operand namespace
+	// which lists the planned changes.
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Creates is the number of the DNS records planned to be created.
+	Creates int32 `json:"creates"`
+
+	// Updates is the number of the DNS records planned to be updated.
+	Updates int32 `json:"updates"`
+
+	// Deletes is the number of the DNS records planned to be deleted.
+	Deletes int32 `json:"deletes"`
+
+	// Message is set when the planned changes of some containers could not be recognized
+	// in their logs, e.g. because the provider doesn't log the changes it plans.
+	// The counts don't include the changes of these containers.
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	// DeletionGuardAcknowledgeAnnotation is the annotation of ExternalDNS
+	// which acknowledges the deletions blocked by the deletion guard.
+	// The deletions of the next plan are applied regardless of the limit,
+	// the annotation is removed by the operator once the plan is evaluated.
+	DeletionGuardAcknowledgeAnnotation = "externaldns.olm.openshift.io/acknowledge-blocked-changes"
+
+	// DebugZoneAnnotation is the annotation of ExternalDNS
+	// which switches the containers of the given zone to the debug log level
+	// until the time set by DebugZoneExpiryAnnotation.
+	DebugZoneAnnotation = "externaldns.olm.openshift.io/debug-zone"
+
+	// DebugZoneExpiryAnnotation is the annotation of ExternalDNS
+	// with the RFC 3339 time after which the zone set by DebugZoneAnnotation
+	// reverts to the log level of the spec.
+	// The annotations are removed by the operator once expired.
+	DebugZoneExpiryAnnotation = "externaldns.olm.openshift.io/debug-zone-expiry"
+)
+
 var (
 	// Available indicates that the ExternalDNS is available.
 	ExternalDNSAvailableConditionType = "Available"
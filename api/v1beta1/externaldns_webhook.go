@@ -19,12 +19,16 @@ package v1beta1
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	utilErrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -36,6 +40,129 @@ var webhookLog = logf.Log.WithName("validating-webhook")
 
 var isOpenShift bool
 
+// gcpQualifiedZoneRegexp matches the GCP zone qualified with the project.
+var gcpQualifiedZoneRegexp = regexp.MustCompile(`^projects/[^/]+/managedZones/[^/]+$`)
+
+// operatorMetadataPrefix is the prefix of the labels and annotations reserved for the operator.
+const operatorMetadataPrefix = "externaldns.olm.openshift.io/"
+
+// reservedOperandLabels are the labels of the operand resources managed by the operator.
+var reservedOperandLabels = map[string]bool{
+	"app.kubernetes.io/name":     true,
+	"app.kubernetes.io/instance": true,
+}
+
+// The registry defaults used by the operator when the registry settings are not given.
+const (
+	defaultRegistryOwnerIDPrefix            = "external-dns"
+	defaultRegistryTXTPrefix                = "external-dns-"
+	defaultRegistryWildcardReplacementAzure = "any"
+	defaultRegistryDynamoDBTable            = "external-dns"
+)
+
+// The bounds of the synchronization settings.
+const (
+	minSyncInterval      = 10 * time.Second
+	maxSyncInterval      = 24 * time.Hour
+	minEventSyncInterval = 1 * time.Second
+	defaultSyncInterval  = 1 * time.Minute
+	minRecordTTL         = 1 * time.Second
+	maxRecordTTL         = 24 * time.Hour
+)
+
+// managedOperandFlags are the flags of ExternalDNS set by the operator
+// which cannot be overridden by the unsupported operand overrides.
+// The tests of the operand deployment check that all the flags set by the operator are listed.
+var managedOperandFlags = map[string]bool{
+	"provider":                   true,
+	"source":                     true,
+	"policy":                     true,
+	"registry":                   true,
+	"txt-owner-id":               true,
+	"txt-prefix":                 true,
+	"txt-suffix":                 true,
+	"txt-wildcard-replacement":   true,
+	"txt-encrypt-enabled":        true,
+	"txt-encrypt-aes-key":        true,
+	"migrate-from-txt-owner":     true,
+	"zone-id-filter":             true,
+	"domain-filter":              true,
+	"exclude-domains":            true,
+	"regex-domain-filter":        true,
+	"regex-domain-exclusion":     true,
+	"label-filter":               true,
+	"service-type-filter":        true,
+	"publish-internal-services":  true,
+	"fqdn-template":              true,
+	"ignore-hostname-annotation": true,
+	"openshift-router-name":      true,
+	"metrics-address":            true,
+	"log-level":                  true,
+	"log-format":                 true,
+	"dry-run":                    true,
+	"once":                       true,
+	"interval":                   true,
+	"events":                     true,
+	"min-event-sync-interval":    true,
+	"min-ttl":                    true,
+	"aws-assume-role":            true,
+	"aws-prefer-cname":           true,
+	"azure-config-file":          true,
+	"azure-subscription-id":      true,
+	"azure-resource-group":       true,
+	"bluecat-config-file":        true,
+	"google-project":             true,
+	"infoblox-grid-host":         true,
+	"infoblox-wapi-port":         true,
+	"infoblox-wapi-version":      true,
+	"infoblox-ssl-verify":        true,
+	"infoblox-view":              true,
+	"infoblox-create-ptr":        true,
+	"infoblox-max-results":       true,
+	"inmemory-zone":              true,
+	"dynamodb-region":            true,
+	"dynamodb-table":             true,
+}
+
+// managedOperandEnvVars are the environment variables of ExternalDNS set by the operator
+// which cannot be overridden by the unsupported operand overrides.
+// The tests of the operand deployment check that all the variables set by the operator are listed.
+var managedOperandEnvVars = map[string]bool{
+	"HTTP_PROXY":                          true,
+	"HTTPS_PROXY":                         true,
+	"NO_PROXY":                            true,
+	"SSL_CERT_DIR":                        true,
+	"AWS_SHARED_CREDENTIALS_FILE":         true,
+	"AWS_REGION":                          true,
+	"GOOGLE_APPLICATION_CREDENTIALS":      true,
+	"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY":    true,
+	"EXTERNAL_DNS_INFOBLOX_WAPI_USERNAME": true,
+	"EXTERNAL_DNS_INFOBLOX_WAPI_PASSWORD": true,
+}
+
+// operandFlagEnvVarPrefix is the prefix of the environment variables
+// which ExternalDNS reads as the values of its flags, e.g. EXTERNAL_DNS_TXT_OWNER_ID for --txt-owner-id.
+const operandFlagEnvVarPrefix = "EXTERNAL_DNS_"
+
+// IsManagedOperandFlag returns true if the given ExternalDNS flag, with or without the value,
+// is managed by the operator. The negated form of the boolean flags is managed too.
+func IsManagedOperandFlag(arg string) bool {
+	flag := strings.SplitN(strings.TrimPrefix(arg, "--"), "=", 2)[0]
+	return managedOperandFlags[flag] || managedOperandFlags[strings.TrimPrefix(flag, "no-")]
+}
+
+// IsManagedOperandEnvVar returns true if the given environment variable of ExternalDNS
+// is set by the operator or sets a flag managed by the operator.
+func IsManagedOperandEnvVar(name string) bool {
+	if managedOperandEnvVars[name] {
+		return true
+	}
+	if !strings.HasPrefix(name, operandFlagEnvVarPrefix) {
+		return false
+	}
+	return IsManagedOperandFlag(strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(name, operandFlagEnvVarPrefix)), "_", "-"))
+}
+
 func (r *ExternalDNS) SetupWebhookWithManager(mgr ctrl.Manager, openshift bool) error {
 	isOpenShift = openshift
 	webhookLog.Info("Setting up the webhook", "IsOpenShift", isOpenShift)
@@ -57,7 +184,7 @@ func (r *ExternalDNS) ValidateCreate() (admission.Warnings, error) {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *ExternalDNS) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	webhookLog.Info("validate update", "name", r.Name)
-	return nil, r.validate(old)
+	return r.policyWarnings(old), r.validate(old)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -73,9 +200,160 @@ func (r *ExternalDNS) validate(old runtime.Object) error {
 		r.validateHostnameAnnotationPolicy(),
 		r.validateProviderCredentials(),
 		r.validateAWSRoleARN(),
+		r.validateZones(),
+		r.validateInfobloxTLS(),
+		r.validateBlueCatConfig(),
+		r.validateInMemoryZones(),
+		r.validateAdditionalProviders(),
+		r.validateRegistry(old),
+		r.validateChangeApproval(),
+		r.validateSync(),
+		r.validateUnsupportedOperandOverrides(),
+		r.validateLogging(),
+		r.validateDebugZone(),
+		r.validateMetrics(),
+		r.validateOperandMetadata(),
 	})
 }
 
+func (r *ExternalDNS) validateChangeApproval() error {
+	if r.Spec.ChangeApproval == ChangeApprovalManual && r.Spec.DryRun {
+		return errors.New("manual change approval cannot be combined with dry run")
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateSync() error {
+	sync := r.Spec.Sync
+	if sync == nil {
+		return nil
+	}
+	interval := defaultSyncInterval
+	if sync.Interval != nil {
+		interval = sync.Interval.Duration
+		if interval < minSyncInterval || interval > maxSyncInterval {
+			return fmt.Errorf("sync interval %s must be between %s and %s", interval, minSyncInterval, maxSyncInterval)
+		}
+	}
+	if sync.MinEventSyncInterval != nil {
+		if !sync.Events {
+			return errors.New("minimum event sync interval can be set only if the events are enabled")
+		}
+		if d := sync.MinEventSyncInterval.Duration; d < minEventSyncInterval || d > interval {
+			return fmt.Errorf("minimum event sync interval %s must be between %s and the sync interval %s", d, minEventSyncInterval, interval)
+		}
+	}
+	if sync.MinTTL != nil {
+		if d := sync.MinTTL.Duration; d < minRecordTTL || d > maxRecordTTL || d%time.Second != 0 {
+			return fmt.Errorf("minimum TTL %s must be a whole number of seconds between %s and %s", d, minRecordTTL, maxRecordTTL)
+		}
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateUnsupportedOperandOverrides() error {
+	overrides := r.Spec.UnsupportedOperandOverrides
+	if overrides == nil {
+		return nil
+	}
+	for _, arg := range overrides.Args {
+		if !strings.HasPrefix(arg, "--") {
+			return fmt.Errorf("unsupported operand override %q must be a long flag starting with \"--\"", arg)
+		}
+		if IsManagedOperandFlag(arg) {
+			flag := strings.SplitN(arg, "=", 2)[0]
+			return fmt.Errorf("unsupported operand override %q cannot override flag %q managed by the operator", arg, flag)
+		}
+	}
+	for _, env := range overrides.Env {
+		if IsManagedOperandEnvVar(env.Name) {
+			return fmt.Errorf("unsupported operand override cannot override environment variable %q managed by the operator", env.Name)
+		}
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateLogging() error {
+	logging := r.Spec.Logging
+	if logging == nil || (logging.Level != LogLevelWarning && logging.Level != LogLevelError) {
+		return nil
+	}
+	// the planned changes are logged at the info level
+	if r.Spec.DryRun || r.Spec.ChangeApproval == ChangeApprovalManual || r.Spec.DeletionGuard != nil {
+		return fmt.Errorf("log level %q hides the planned changes required by the dry run, the manual change approval and the deletion guard", logging.Level)
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateMetrics() error {
+	metrics := r.Spec.Metrics
+	if metrics == nil || metrics.ScrapeInterval == nil {
+		return nil
+	}
+	if !metrics.ServiceMonitor {
+		return errors.New("scrape interval can be set only if the service monitor is enabled")
+	}
+	if d := metrics.ScrapeInterval.Duration; d < time.Second || d%time.Second != 0 {
+		return fmt.Errorf("scrape interval %s must be a whole number of seconds", d)
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateOperandMetadata() error {
+	metadata := r.Spec.OperandMetadata
+	if metadata == nil {
+		return nil
+	}
+	for key, value := range metadata.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("operand label %q is invalid: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("value %q of operand label %q is invalid: %s", value, key, strings.Join(errs, "; "))
+		}
+		if reservedOperandLabels[key] || strings.HasPrefix(key, operatorMetadataPrefix) {
+			return fmt.Errorf("operand label %q is reserved for the operator", key)
+		}
+	}
+	for key := range metadata.Annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("operand annotation %q is invalid: %s", key, strings.Join(errs, "; "))
+		}
+		if strings.HasPrefix(key, operatorMetadataPrefix) {
+			return fmt.Errorf("operand annotation %q is reserved for the operator", key)
+		}
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateDebugZone() error {
+	zone, debugged := r.Annotations[DebugZoneAnnotation]
+	expiry, expiring := r.Annotations[DebugZoneExpiryAnnotation]
+	if !debugged && !expiring {
+		return nil
+	}
+	if len(zone) == 0 {
+		return fmt.Errorf("annotation %q must be set to the debugged zone", DebugZoneAnnotation)
+	}
+	if _, err := time.Parse(time.RFC3339, expiry); err != nil {
+		return fmt.Errorf("annotation %q must be set to the RFC 3339 expiry time of the debug zone: %w", DebugZoneExpiryAnnotation, err)
+	}
+	return nil
+}
+
+// policyWarnings warns about the switch of an existing instance to the Sync policy
+// as the records of the removed sources start to be deleted.
+func (r *ExternalDNS) policyWarnings(old runtime.Object) admission.Warnings {
+	oldR, ok := old.(*ExternalDNS)
+	if !ok {
+		return nil
+	}
+	if oldPolicy, newPolicy := oldR.policy(), r.policy(); oldPolicy != PolicySync && newPolicy == PolicySync {
+		return admission.Warnings{fmt.Sprintf("policy is changed from %q to %q: the DNS records whose sources no longer exist will be deleted", oldPolicy, newPolicy)}
+	}
+	return nil
+}
+
 func (r *ExternalDNS) validateSources(old runtime.Object) error {
 	if old != nil {
 		if oldR, ok := old.(*ExternalDNS); ok {
@@ -133,7 +411,10 @@ func (r *ExternalDNS) validateProviderCredentials() error {
 	if isOpenShift && (r.Spec.Provider.Type == ProviderTypeAWS || r.Spec.Provider.Type == ProviderTypeGCP || r.Spec.Provider.Type == ProviderTypeAzure) {
 		return nil
 	}
-	provider := r.Spec.Provider
+	return r.Spec.Provider.validateCredentials()
+}
+
+func (provider ExternalDNSProvider) validateCredentials() error {
 	switch provider.Type {
 	case ProviderTypeAWS:
 		if provider.AWS == nil || provider.AWS.Credentials.Name == "" {
@@ -148,8 +429,8 @@ func (r *ExternalDNS) validateProviderCredentials() error {
 			return errors.New("credentials secret must be specified when provider type is GCP")
 		}
 	case ProviderTypeBlueCat:
-		if provider.BlueCat == nil || provider.BlueCat.ConfigFile.Name == "" {
-			return errors.New("config file name must be specified when provider type is BlueCat")
+		if provider.BlueCat == nil || (provider.BlueCat.ConfigFile.Name == "" && provider.BlueCat.Credentials == nil) {
+			return errors.New("config file name must be specified when provider type is BlueCat, unless the credentials secret is given")
 		}
 	case ProviderTypeInfoblox:
 		if provider.Infoblox == nil || provider.Infoblox.WAPIVersion == "" || provider.Infoblox.WAPIPort == 0 || provider.Infoblox.GridHost == "" || provider.Infoblox.Credentials.Name == "" {
@@ -168,3 +449,230 @@ func (r *ExternalDNS) validateAWSRoleARN() error {
 
 	return nil
 }
+
+func (r *ExternalDNS) validateZones() error {
+	if r.Spec.Provider.Type != ProviderTypeGCP {
+		return nil
+	}
+	for _, zone := range r.Spec.Zones {
+		if strings.HasPrefix(zone, "projects/") && !gcpQualifiedZoneRegexp.MatchString(zone) {
+			return fmt.Errorf(`zone %q must be of the form "projects/<project>/managedZones/<zone>"`, zone)
+		}
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateInfobloxTLS() error {
+	infoblox := r.Spec.Provider.Infoblox
+	if r.Spec.Provider.Type != ProviderTypeInfoblox || infoblox == nil {
+		return nil
+	}
+	if infoblox.SkipTLSVerify && infoblox.GridCA != nil {
+		return errors.New(`"GridCA" cannot be specified when "SkipTLSVerify" is set`)
+	}
+	if infoblox.GridCA != nil && infoblox.GridCA.Name == "" {
+		return errors.New(`"GridCA" config map name cannot be empty`)
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateBlueCatConfig() error {
+	bluecat := r.Spec.Provider.BlueCat
+	if r.Spec.Provider.Type != ProviderTypeBlueCat || bluecat == nil || bluecat.Credentials == nil {
+		return nil
+	}
+	if bluecat.ConfigFile.Name != "" {
+		return errors.New(`"ConfigFile" and "Credentials" cannot be specified together when provider is BlueCat`)
+	}
+	if bluecat.Credentials.Name == "" {
+		return errors.New(`"Credentials" secret name cannot be empty when provider is BlueCat`)
+	}
+	if bluecat.GatewayHost == "" || bluecat.DNSConfiguration == "" || bluecat.DNSView == "" {
+		return errors.New(`"GatewayHost", "DNSConfiguration" and "DNSView" must be specified when BlueCat credentials are given`)
+	}
+	gatewayURL, err := url.Parse(bluecat.GatewayHost)
+	if err != nil || (gatewayURL.Scheme != "http" && gatewayURL.Scheme != "https") || gatewayURL.Host == "" {
+		return fmt.Errorf(`"GatewayHost" %q must be an http or https URL`, bluecat.GatewayHost)
+	}
+	if bluecat.DeployOnChange && bluecat.DNSServerName == "" {
+		return errors.New(`"DNSServerName" must be specified when "DeployOnChange" is set`)
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateInMemoryZones() error {
+	if r.Spec.Provider.Type == ProviderTypeInMemory && len(r.Spec.Zones) == 0 {
+		return errors.New("zones must be specified when provider type is InMemory")
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateAdditionalProviders() error {
+	seen := map[ExternalDNSProviderType]bool{r.Spec.Provider.Type: true}
+	errs := []error{}
+	for _, target := range r.Spec.AdditionalProviders {
+		if seen[target.Type] {
+			errs = append(errs, fmt.Errorf("provider type %q cannot be specified more than once", target.Type))
+			continue
+		}
+		seen[target.Type] = true
+
+		if target.Infoblox != nil && target.Infoblox.GridCA != nil {
+			errs = append(errs, fmt.Errorf(`additional provider %q: "GridCA" is supported only for the primary provider`, target.Type))
+		}
+
+		// validate the target as if it was the only provider
+		targetR := r.DeepCopy()
+		targetR.Spec.Provider = target.ExternalDNSProvider
+		targetR.Spec.Zones = target.Zones
+		if err := utilErrors.NewAggregate([]error{
+			target.validateCredentials(),
+			targetR.validateAWSRoleARN(),
+			targetR.validateZones(),
+			targetR.validateInfobloxTLS(),
+			targetR.validateBlueCatConfig(),
+			targetR.validateInMemoryZones(),
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("additional provider %q: %w", target.Type, err))
+		}
+	}
+	return utilErrors.NewAggregate(errs)
+}
+
+func (r *ExternalDNS) validateRegistry(old runtime.Object) error {
+	registry := r.Spec.Registry
+	if registry != nil && registry.TXTPrefix != "" && registry.TXTSuffix != "" {
+		return errors.New(`"TXTPrefix" and "TXTSuffix" cannot be specified together`)
+	}
+
+	if registry != nil && registry.Encryption != nil && registry.Encryption.Key.Name == "" {
+		return errors.New("encryption key secret name cannot be empty")
+	}
+
+	if err := r.validateRegistryType(); err != nil {
+		return err
+	}
+
+	if registry != nil && registry.MigrateFromOwnerID != "" {
+		if r.registryType() != RegistryTypeTXT {
+			return errors.New("owner ID migration is supported only for TXT registry")
+		}
+		if registry.MigrateFromOwnerID == r.registryOwnerID() {
+			return fmt.Errorf("owner ID to migrate from cannot be the same as the current owner ID %q", registry.MigrateFromOwnerID)
+		}
+	}
+
+	if registry != nil && registry.AllowOwnershipChange {
+		return nil
+	}
+	oldR, ok := old.(*ExternalDNS)
+	if !ok || oldR == nil {
+		return nil
+	}
+
+	// the records created with the previous settings
+	// are not recognized as owned by ExternalDNS anymore
+	errs := []error{}
+	if oldType, newType := oldR.registryType(), r.registryType(); oldType != newType {
+		errs = append(errs, fmt.Errorf("registry type cannot be changed from %q to %q unless ownership change is allowed", oldType, newType))
+	} else if oldTable, newTable := oldR.registryDynamoDBTable(), r.registryDynamoDBTable(); oldTable != newTable {
+		errs = append(errs, fmt.Errorf("DynamoDB table cannot be changed from %q to %q unless ownership change is allowed", oldTable, newTable))
+	}
+	// the records of the previous owner are taken over by the migration
+	if oldID, newID := oldR.registryOwnerID(), r.registryOwnerID(); oldID != newID && (registry == nil || registry.MigrateFromOwnerID != oldID) {
+		errs = append(errs, fmt.Errorf("owner ID cannot be changed from %q to %q unless ownership change is allowed", oldID, newID))
+	}
+	if oldAffix, newAffix := oldR.registryTXTAffix(), r.registryTXTAffix(); oldAffix != newAffix {
+		errs = append(errs, fmt.Errorf("TXT record %s cannot be changed to %s unless ownership change is allowed", oldAffix, newAffix))
+	}
+	if oldRepl, newRepl := oldR.registryWildcardReplacement(), r.registryWildcardReplacement(); oldRepl != newRepl {
+		errs = append(errs, fmt.Errorf("wildcard replacement cannot be changed from %q to %q unless ownership change is allowed", oldRepl, newRepl))
+	}
+	return utilErrors.NewAggregate(errs)
+}
+
+func (r *ExternalDNS) validateRegistryType() error {
+	registry := r.Spec.Registry
+	if registry == nil {
+		return nil
+	}
+	if r.registryType() != RegistryTypeDynamoDB {
+		if registry.DynamoDB != nil {
+			return errors.New(`"DynamoDB" options can be specified only when registry type is DynamoDB`)
+		}
+		return nil
+	}
+	if r.Spec.Provider.Type != ProviderTypeAWS {
+		return errors.New("DynamoDB registry is supported only when provider type is AWS")
+	}
+	if len(r.Spec.AdditionalProviders) != 0 {
+		return errors.New("DynamoDB registry cannot be used along with additional providers")
+	}
+	if registry.Encryption != nil {
+		return errors.New("encryption is supported only for TXT registry")
+	}
+	return nil
+}
+
+// policy returns the synchronization policy used by ExternalDNS.
+func (r *ExternalDNS) policy() ExternalDNSPolicy {
+	if r.Spec.Policy != "" {
+		return r.Spec.Policy
+	}
+	return PolicySync
+}
+
+// registryType returns the type of the registry used by ExternalDNS.
+func (r *ExternalDNS) registryType() ExternalDNSRegistryType {
+	if r.Spec.Registry != nil && r.Spec.Registry.Type != "" {
+		return r.Spec.Registry.Type
+	}
+	return RegistryTypeTXT
+}
+
+// registryDynamoDBTable returns the DynamoDB table used by the registry of ExternalDNS,
+// empty if the registry is not DynamoDB.
+func (r *ExternalDNS) registryDynamoDBTable() string {
+	if r.registryType() != RegistryTypeDynamoDB {
+		return ""
+	}
+	if r.Spec.Registry.DynamoDB != nil && r.Spec.Registry.DynamoDB.Table != "" {
+		return r.Spec.Registry.DynamoDB.Table
+	}
+	return defaultRegistryDynamoDBTable
+}
+
+// registryOwnerID returns the owner ID used by the registry of ExternalDNS.
+func (r *ExternalDNS) registryOwnerID() string {
+	if r.Spec.Registry != nil && r.Spec.Registry.OwnerID != "" {
+		return r.Spec.Registry.OwnerID
+	}
+	return defaultRegistryOwnerIDPrefix + "-" + r.Name
+}
+
+// registryTXTAffix returns the description of the affix of the TXT records used by the registry of ExternalDNS.
+func (r *ExternalDNS) registryTXTAffix() string {
+	if r.Spec.Registry != nil && r.Spec.Registry.TXTSuffix != "" {
+		return fmt.Sprintf("suffix %q", r.Spec.Registry.TXTSuffix)
+	}
+	if r.Spec.Registry != nil && r.Spec.Registry.TXTPrefix != "" {
+		return fmt.Sprintf("prefix %q", r.Spec.Registry.TXTPrefix)
+	}
+	// the records of the in-memory provider are not shared with anyone,
+	// no affix is added unless it's given explicitly
+	if r.Spec.Provider.Type == ProviderTypeInMemory {
+		return fmt.Sprintf("prefix %q", "")
+	}
+	return fmt.Sprintf("prefix %q", defaultRegistryTXTPrefix)
+}
+
+// registryWildcardReplacement returns the wildcard replacement used by the registry of ExternalDNS.
+func (r *ExternalDNS) registryWildcardReplacement() string {
+	if r.Spec.Registry != nil && r.Spec.Registry.WildcardReplacement != "" {
+		return r.Spec.Registry.WildcardReplacement
+	}
+	if r.Spec.Provider.Type == ProviderTypeAzure {
+		return defaultRegistryWildcardReplacementAzure
+	}
+	return ""
+}
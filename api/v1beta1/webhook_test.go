@@ -2,9 +2,11 @@ package v1beta1
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -251,6 +253,21 @@ var _ = Describe("ExternalDNS admission webhook", func() {
 			Expect(err).ShouldNot(Succeed())
 			Expect(err.Error()).Should(ContainSubstring("credentials secret must be specified when provider type is GCP"))
 		})
+		It("accepts zones qualified with the project", func() {
+			resource := makeExternalDNS("test-gcp-qualified-zones", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeGCP, GCP: &ExternalDNSGCPProviderOptions{Credentials: SecretReference{Name: "credentials"}}}
+			resource.Spec.Zones = []string{"spoke-zone", "projects/hub-project/managedZones/hub-zone"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+		It("rejects malformed zones qualified with the project", func() {
+			resource := makeExternalDNS("test-gcp-malformed-zones", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeGCP, GCP: &ExternalDNSGCPProviderOptions{Credentials: SecretReference{Name: "credentials"}}}
+			resource.Spec.Zones = []string{"projects/hub-project/hub-zone"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`zone "projects/hub-project/hub-zone" must be of the form "projects/<project>/managedZones/<zone>"`))
+		})
 	})
 
 	Context("resource with Bluecat provider", func() {
@@ -261,6 +278,58 @@ var _ = Describe("ExternalDNS admission webhook", func() {
 			Expect(err).ShouldNot(Succeed())
 			Expect(err.Error()).Should(ContainSubstring("config file name must be specified when provider type is BlueCat"))
 		})
+
+		It("accepted with structured configuration", func() {
+			resource := makeExternalDNS("test-bluecat-structured", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeBlueCat, BlueCat: &ExternalDNSBlueCatProviderOptions{
+				Credentials:      &SecretReference{Name: "bluecat-credentials"},
+				GatewayHost:      "https://bluecatgw.example.com",
+				DNSConfiguration: "Example",
+				DNSView:          "Internal",
+				RootZone:         "example.com",
+				DNSServerName:    "ns1.example.com",
+				DeployOnChange:   true,
+			}}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected when structured configuration is incomplete", func() {
+			resource := makeExternalDNS("test-bluecat-structured-incomplete", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeBlueCat, BlueCat: &ExternalDNSBlueCatProviderOptions{
+				Credentials: &SecretReference{Name: "bluecat-credentials"},
+				GatewayHost: "https://bluecatgw.example.com",
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"GatewayHost", "DNSConfiguration" and "DNSView" must be specified when BlueCat credentials are given`))
+		})
+
+		It("rejected when gateway host is not a URL", func() {
+			resource := makeExternalDNS("test-bluecat-structured-bad-host", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeBlueCat, BlueCat: &ExternalDNSBlueCatProviderOptions{
+				Credentials:      &SecretReference{Name: "bluecat-credentials"},
+				GatewayHost:      "bluecatgw.example.com",
+				DNSConfiguration: "Example",
+				DNSView:          "Internal",
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("must be an http or https URL"))
+		})
+
+		It("rejected when deploy on change is set without DNS server name", func() {
+			resource := makeExternalDNS("test-bluecat-structured-no-server", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeBlueCat, BlueCat: &ExternalDNSBlueCatProviderOptions{
+				Credentials:      &SecretReference{Name: "bluecat-credentials"},
+				GatewayHost:      "https://bluecatgw.example.com",
+				DNSConfiguration: "Example",
+				DNSView:          "Internal",
+				DeployOnChange:   true,
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"DNSServerName" must be specified when "DeployOnChange" is set`))
+		})
 	})
 
 	Context("resource with Infobox provider", func() {
@@ -311,6 +380,469 @@ var _ = Describe("ExternalDNS admission webhook", func() {
 			Expect(err).ShouldNot(Succeed())
 			Expect(err.Error()).Should(ContainSubstring(`"WAPIVersion", "WAPIPort", "GridHost" and credentials file must be specified when provider is Infoblox`))
 		})
+
+		It("rejected when grid CA is specified along with skipped TLS verification", func() {
+			resource := makeExternalDNS("test-infoblox-skip-tls-grid-ca", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeInfoblox, Infoblox: &ExternalDNSInfobloxProviderOptions{
+				Credentials:   SecretReference{Name: "infoblox-credentials"},
+				GridHost:      "127.0.0.1",
+				WAPIPort:      1977,
+				WAPIVersion:   "v1",
+				SkipTLSVerify: true,
+				GridCA:        &ConfigMapReference{Name: "infoblox-grid-ca"},
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"GridCA" cannot be specified when "SkipTLSVerify" is set`))
+		})
+
+		It("accepted with view, PTR creation and grid CA", func() {
+			resource := makeExternalDNS("test-infoblox-options", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeInfoblox, Infoblox: &ExternalDNSInfobloxProviderOptions{
+				Credentials: SecretReference{Name: "infoblox-credentials"},
+				GridHost:    "127.0.0.1",
+				WAPIPort:    1977,
+				WAPIVersion: "v1",
+				View:        "internal",
+				CreatePTR:   true,
+				MaxResults:  2000,
+				GridCA:      &ConfigMapReference{Name: "infoblox-grid-ca"},
+			}}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+	})
+
+	Context("resource with InMemory provider", func() {
+		It("rejected when zones are not specified", func() {
+			resource := makeExternalDNS("test-inmemory-no-zones", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeInMemory}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("zones must be specified when provider type is InMemory"))
+		})
+
+		It("accepted without credentials", func() {
+			resource := makeExternalDNS("test-inmemory", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeInMemory}
+			resource.Spec.Zones = []string{"example.com"}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected when the default TXT record prefix is given explicitly", func() {
+			resource := makeExternalDNS("test-inmemory-txt-prefix", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeInMemory}
+			resource.Spec.Zones = []string{"example.com"}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+
+			resource.Spec.Registry = &ExternalDNSRegistry{TXTPrefix: "external-dns-"}
+			err := k8sClient.Update(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`TXT record prefix "" cannot be changed to prefix "external-dns-"`))
+		})
+	})
+
+	Context("resource with additional providers", func() {
+		It("rejected when provider type is specified more than once", func() {
+			resource := makeExternalDNS("test-additional-duplicate", nil)
+			resource.Spec.AdditionalProviders = []ExternalDNSProviderTarget{
+				{
+					ExternalDNSProvider: ExternalDNSProvider{
+						Type: ProviderTypeAWS,
+						AWS:  &ExternalDNSAWSProviderOptions{Credentials: SecretReference{Name: "credentials"}},
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`provider type "AWS" cannot be specified more than once`))
+		})
+
+		It("rejected when additional provider credentials are not specified", func() {
+			resource := makeExternalDNS("test-additional-no-credentials", nil)
+			resource.Spec.AdditionalProviders = []ExternalDNSProviderTarget{
+				{
+					ExternalDNSProvider: ExternalDNSProvider{Type: ProviderTypeAzure},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`additional provider "Azure": config file name must be specified when provider type is Azure`))
+		})
+
+		It("accepted with credentials and zones", func() {
+			resource := makeExternalDNS("test-additional", nil)
+			resource.Spec.AdditionalProviders = []ExternalDNSProviderTarget{
+				{
+					ExternalDNSProvider: ExternalDNSProvider{
+						Type:  ProviderTypeAzure,
+						Azure: &ExternalDNSAzureProviderOptions{ConfigFile: SecretReference{Name: "azure-config"}},
+					},
+					Zones: []string{"/subscriptions/xxx/resourceGroups/rg/providers/Microsoft.Network/dnszones/example.com"},
+				},
+				{
+					ExternalDNSProvider: ExternalDNSProvider{Type: ProviderTypeInMemory},
+					Zones:               []string{"example.com"},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+	})
+
+	Context("resource with registry settings", func() {
+		It("rejected when both TXT prefix and suffix are specified", func() {
+			resource := makeExternalDNS("test-registry-affixes", nil)
+			resource.Spec.Registry = &ExternalDNSRegistry{TXTPrefix: "prefix-", TXTSuffix: "-suffix"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"TXTPrefix" and "TXTSuffix" cannot be specified together`))
+		})
+
+		It("rejected when encryption key secret is not specified", func() {
+			resource := makeExternalDNS("test-registry-encryption", nil)
+			resource.Spec.Registry = &ExternalDNSRegistry{Encryption: &ExternalDNSRegistryEncryption{}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("encryption key secret name cannot be empty"))
+		})
+
+		It("rejected when DynamoDB registry is used with non AWS provider", func() {
+			resource := makeExternalDNS("test-registry-dynamodb-gcp", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeGCP,
+				GCP:  &ExternalDNSGCPProviderOptions{Credentials: SecretReference{Name: "credentials"}},
+			}
+			resource.Spec.Registry = &ExternalDNSRegistry{Type: RegistryTypeDynamoDB}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("DynamoDB registry is supported only when provider type is AWS"))
+		})
+
+		It("rejected when registry type is changed", func() {
+			resource := makeExternalDNS("test-registry-type", nil)
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+
+			resource.Spec.Registry = &ExternalDNSRegistry{Type: RegistryTypeDynamoDB}
+			err := k8sClient.Update(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`registry type cannot be changed from "TXT" to "DynamoDB"`))
+		})
+
+		It("accepted with DynamoDB registry", func() {
+			resource := makeExternalDNS("test-registry-dynamodb", nil)
+			resource.Spec.Registry = &ExternalDNSRegistry{
+				Type:     RegistryTypeDynamoDB,
+				DynamoDB: &ExternalDNSDynamoDBRegistryOptions{Table: "ownership", Region: "us-east-1"},
+			}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected when owner ID is changed", func() {
+			resource := makeExternalDNS("test-registry-owner", nil)
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+
+			resource.Spec.Registry = &ExternalDNSRegistry{OwnerID: "legacy-owner", TXTSuffix: "-owner"}
+			err := k8sClient.Update(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`owner ID cannot be changed from "external-dns-test-registry-owner" to "legacy-owner"`))
+			Expect(err.Error()).Should(ContainSubstring(`TXT record prefix "external-dns-" cannot be changed to suffix "-owner"`))
+		})
+
+		It("accepted when default settings are given explicitly", func() {
+			resource := makeExternalDNS("test-registry-defaults", nil)
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+
+			resource.Spec.Registry = &ExternalDNSRegistry{OwnerID: "external-dns-test-registry-defaults", TXTPrefix: "external-dns-"}
+			Expect(k8sClient.Update(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("accepted when ownership change is allowed", func() {
+			resource := makeExternalDNS("test-registry-migration", nil)
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+
+			resource.Spec.Registry = &ExternalDNSRegistry{OwnerID: "legacy-owner", TXTSuffix: "-owner", AllowOwnershipChange: true}
+			Expect(k8sClient.Update(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected when owner ID to migrate from is the current one", func() {
+			resource := makeExternalDNS("test-registry-migrate-same", nil)
+			resource.Spec.Registry = &ExternalDNSRegistry{OwnerID: "my-owner", MigrateFromOwnerID: "my-owner"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`owner ID to migrate from cannot be the same as the current owner ID "my-owner"`))
+		})
+
+		It("rejected when owner ID migration is requested for DynamoDB registry", func() {
+			resource := makeExternalDNS("test-registry-migrate-dynamodb", nil)
+			resource.Spec.Registry = &ExternalDNSRegistry{Type: RegistryTypeDynamoDB, MigrateFromOwnerID: "old-owner"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("owner ID migration is supported only for TXT registry"))
+		})
+
+		It("accepted when owner ID is changed with migration from the previous one", func() {
+			resource := makeExternalDNS("test-registry-migrate", nil)
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+
+			resource.Spec.Registry = &ExternalDNSRegistry{OwnerID: "new-owner", MigrateFromOwnerID: "external-dns-test-registry-migrate"}
+			Expect(k8sClient.Update(context.Background(), resource)).Should(Succeed())
+		})
+	})
+
+	Context("resource with policy", func() {
+		It("accepted with upsert-only policy", func() {
+			resource := makeExternalDNS("test-policy-upsert", nil)
+			resource.Spec.Policy = PolicyUpsertOnly
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("warned when switched to sync policy", func() {
+			old := makeExternalDNS("test-policy-sync", nil)
+			old.Spec.Policy = PolicyCreateOnly
+			resource := old.DeepCopy()
+			resource.Spec.Policy = PolicySync
+			warnings, err := resource.ValidateUpdate(old)
+			Expect(err).Should(Succeed())
+			Expect(warnings).Should(ConsistOf(ContainSubstring(`policy is changed from "CreateOnly" to "Sync"`)))
+		})
+
+		It("not warned when sync policy is kept", func() {
+			old := makeExternalDNS("test-policy-default", nil)
+			resource := old.DeepCopy()
+			resource.Spec.Policy = PolicySync
+			warnings, err := resource.ValidateUpdate(old)
+			Expect(err).Should(Succeed())
+			Expect(warnings).Should(BeEmpty())
+		})
+	})
+
+	Context("resource with change approval", func() {
+		It("accepted with manual change approval", func() {
+			resource := makeExternalDNS("test-approval-manual", nil)
+			resource.Spec.ChangeApproval = ChangeApprovalManual
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected with manual change approval and dry run", func() {
+			resource := makeExternalDNS("test-approval-dry-run", nil)
+			resource.Spec.ChangeApproval = ChangeApprovalManual
+			resource.Spec.DryRun = true
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("manual change approval cannot be combined with dry run"))
+		})
+	})
+
+	Context("resource with sync settings", func() {
+		It("accepted with sync settings within bounds", func() {
+			resource := makeExternalDNS("test-sync-valid", nil)
+			resource.Spec.Sync = &ExternalDNSSyncOptions{
+				Interval:             &metav1.Duration{Duration: 5 * time.Minute},
+				Events:               true,
+				MinEventSyncInterval: &metav1.Duration{Duration: 10 * time.Second},
+				MinTTL:               &metav1.Duration{Duration: 5 * time.Minute},
+			}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected with too short sync interval", func() {
+			resource := makeExternalDNS("test-sync-short-interval", nil)
+			resource.Spec.Sync = &ExternalDNSSyncOptions{
+				Interval: &metav1.Duration{Duration: time.Second},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("sync interval 1s must be between 10s and 24h0m0s"))
+		})
+
+		It("rejected with minimum event sync interval longer than sync interval", func() {
+			resource := makeExternalDNS("test-sync-long-event-interval", nil)
+			resource.Spec.Sync = &ExternalDNSSyncOptions{
+				Events:               true,
+				MinEventSyncInterval: &metav1.Duration{Duration: 2 * time.Minute},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("minimum event sync interval 2m0s must be between 1s and the sync interval 1m0s"))
+		})
+
+		It("rejected with minimum event sync interval without events", func() {
+			resource := makeExternalDNS("test-sync-no-events", nil)
+			resource.Spec.Sync = &ExternalDNSSyncOptions{
+				MinEventSyncInterval: &metav1.Duration{Duration: 10 * time.Second},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("minimum event sync interval can be set only if the events are enabled"))
+		})
+
+		It("rejected with fractional minimum TTL", func() {
+			resource := makeExternalDNS("test-sync-fractional-ttl", nil)
+			resource.Spec.Sync = &ExternalDNSSyncOptions{
+				MinTTL: &metav1.Duration{Duration: 1500 * time.Millisecond},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("minimum TTL 1.5s must be a whole number of seconds between 1s and 24h0m0s"))
+		})
+	})
+
+	Context("resource with unsupported operand overrides", func() {
+		It("accepted with unmanaged flags", func() {
+			resource := makeExternalDNS("test-overrides-valid", nil)
+			resource.Spec.UnsupportedOperandOverrides = &ExternalDNSUnsupportedOperandOverrides{
+				Args: []string{"--aws-batch-change-size=100", "--no-aws-evaluate-target-health"},
+				Env:  []corev1.EnvVar{{Name: "AWS_MAX_ATTEMPTS", Value: "5"}},
+			}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected with managed flag", func() {
+			resource := makeExternalDNS("test-overrides-managed", nil)
+			resource.Spec.UnsupportedOperandOverrides = &ExternalDNSUnsupportedOperandOverrides{
+				Args: []string{"--txt-owner-id=other"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`unsupported operand override "--txt-owner-id=other" cannot override flag "--txt-owner-id" managed by the operator`))
+		})
+
+		It("rejected with negated managed flag", func() {
+			resource := makeExternalDNS("test-overrides-negated", nil)
+			resource.Spec.UnsupportedOperandOverrides = &ExternalDNSUnsupportedOperandOverrides{
+				Args: []string{"--no-dry-run"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`cannot override flag "--no-dry-run" managed by the operator`))
+		})
+
+		It("rejected with managed provider flag", func() {
+			resource := makeExternalDNS("test-overrides-managed-provider", nil)
+			resource.Spec.UnsupportedOperandOverrides = &ExternalDNSUnsupportedOperandOverrides{
+				Args: []string{"--aws-prefer-cname"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`cannot override flag "--aws-prefer-cname" managed by the operator`))
+		})
+
+		It("rejected with managed environment variable", func() {
+			resource := makeExternalDNS("test-overrides-managed-env", nil)
+			resource.Spec.UnsupportedOperandOverrides = &ExternalDNSUnsupportedOperandOverrides{
+				Env: []corev1.EnvVar{{Name: "AWS_SHARED_CREDENTIALS_FILE", Value: "/tmp/credentials"}},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`cannot override environment variable "AWS_SHARED_CREDENTIALS_FILE" managed by the operator`))
+		})
+
+		It("rejected with environment variable of managed flag", func() {
+			resource := makeExternalDNS("test-overrides-flag-env", nil)
+			resource.Spec.UnsupportedOperandOverrides = &ExternalDNSUnsupportedOperandOverrides{
+				Env: []corev1.EnvVar{{Name: "EXTERNAL_DNS_TXT_OWNER_ID", Value: "other"}},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`cannot override environment variable "EXTERNAL_DNS_TXT_OWNER_ID" managed by the operator`))
+		})
+
+		It("rejected with positional argument", func() {
+			resource := makeExternalDNS("test-overrides-positional", nil)
+			resource.Spec.UnsupportedOperandOverrides = &ExternalDNSUnsupportedOperandOverrides{
+				Args: []string{"aws"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`unsupported operand override "aws" must be a long flag starting with "--"`))
+		})
+	})
+
+	Context("resource with logging", func() {
+		It("accepted with warning level", func() {
+			resource := makeExternalDNS("test-logging-warning", nil)
+			resource.Spec.Logging = &ExternalDNSLogging{Level: LogLevelWarning, Format: LogFormatJSON}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected with error level and dry run", func() {
+			resource := makeExternalDNS("test-logging-dry-run", nil)
+			resource.Spec.DryRun = true
+			resource.Spec.Logging = &ExternalDNSLogging{Level: LogLevelError}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`log level "Error" hides the planned changes`))
+		})
+
+		It("accepted with debug zone", func() {
+			resource := makeExternalDNS("test-debug-zone-valid", nil)
+			resource.Annotations = map[string]string{
+				DebugZoneAnnotation:       "my-dns-public-zone",
+				DebugZoneExpiryAnnotation: "2030-01-01T00:00:00Z",
+			}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected with malformed debug zone expiry", func() {
+			resource := makeExternalDNS("test-debug-zone-expiry", nil)
+			resource.Annotations = map[string]string{
+				DebugZoneAnnotation:       "my-dns-public-zone",
+				DebugZoneExpiryAnnotation: "1h",
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`annotation "externaldns.olm.openshift.io/debug-zone-expiry" must be set to the RFC 3339 expiry time`))
+		})
+
+		It("rejected with debug zone expiry only", func() {
+			resource := makeExternalDNS("test-debug-zone-missing", nil)
+			resource.Annotations = map[string]string{
+				DebugZoneExpiryAnnotation: "2030-01-01T00:00:00Z",
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`annotation "externaldns.olm.openshift.io/debug-zone" must be set to the debugged zone`))
+		})
+	})
+
+	Context("resource with operand metadata", func() {
+		It("accepted with custom labels and annotations", func() {
+			resource := makeExternalDNS("test-operand-metadata-valid", nil)
+			resource.Spec.OperandMetadata = &ExternalDNSOperandMetadata{
+				Labels:      map[string]string{"cost-center": "dns", "example.com/team": "network"},
+				Annotations: map[string]string{"sidecar.istio.io/inject": "false"},
+			}
+			Expect(k8sClient.Create(context.Background(), resource)).Should(Succeed())
+		})
+
+		It("rejected with reserved label", func() {
+			resource := makeExternalDNS("test-operand-metadata-label", nil)
+			resource.Spec.OperandMetadata = &ExternalDNSOperandMetadata{
+				Labels: map[string]string{"app.kubernetes.io/instance": "other"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`operand label "app.kubernetes.io/instance" is reserved for the operator`))
+		})
+
+		It("rejected with invalid label value", func() {
+			resource := makeExternalDNS("test-operand-metadata-value", nil)
+			resource.Spec.OperandMetadata = &ExternalDNSOperandMetadata{
+				Labels: map[string]string{"team": "network team"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`value "network team" of operand label "team" is invalid`))
+		})
+
+		It("rejected with reserved annotation", func() {
+			resource := makeExternalDNS("test-operand-metadata-annotation", nil)
+			resource.Spec.OperandMetadata = &ExternalDNSOperandMetadata{
+				Annotations: map[string]string{"externaldns.olm.openshift.io/credentials-secret-hash": "0"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`operand annotation "externaldns.olm.openshift.io/credentials-secret-hash" is reserved for the operator`))
+		})
 	})
 
 	Context("resource with multiple missing fields", func() {
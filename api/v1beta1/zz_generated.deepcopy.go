@@ -22,11 +22,150 @@ limitations under the License.
 package v1beta1
 
 import (
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSChangeRequest) DeepCopyInto(out *DNSChangeRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChangeRequest.
+func (in *DNSChangeRequest) DeepCopy() *DNSChangeRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSChangeRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSChangeRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSChangeRequestList) DeepCopyInto(out *DNSChangeRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSChangeRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChangeRequestList.
+func (in *DNSChangeRequestList) DeepCopy() *DNSChangeRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSChangeRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSChangeRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSChangeRequestSpec) DeepCopyInto(out *DNSChangeRequestSpec) {
+	*out = *in
+	in.Changes.DeepCopyInto(&out.Changes)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChangeRequestSpec.
+func (in *DNSChangeRequestSpec) DeepCopy() *DNSChangeRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSChangeRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSChangeRequestStatus) DeepCopyInto(out *DNSChangeRequestStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChangeRequestStatus.
+func (in *DNSChangeRequestStatus) DeepCopy() *DNSChangeRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSChangeRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSChanges) DeepCopyInto(out *DNSChanges) {
+	*out = *in
+	if in.Creates != nil {
+		in, out := &in.Creates, &out.Creates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Updates != nil {
+		in, out := &in.Updates, &out.Updates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deletes != nil {
+		in, out := &in.Deletes, &out.Deletes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChanges.
+func (in *DNSChanges) DeepCopy() *DNSChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNS) DeepCopyInto(out *ExternalDNS) {
 	*out = *in
@@ -110,6 +249,11 @@ func (in *ExternalDNSAzureProviderOptions) DeepCopy() *ExternalDNSAzureProviderO
 func (in *ExternalDNSBlueCatProviderOptions) DeepCopyInto(out *ExternalDNSBlueCatProviderOptions) {
 	*out = *in
 	out.ConfigFile = in.ConfigFile
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(SecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSBlueCatProviderOptions.
@@ -127,7 +271,7 @@ func (in *ExternalDNSCRDSourceOptions) DeepCopyInto(out *ExternalDNSCRDSourceOpt
 	*out = *in
 	if in.LabelFilter != nil {
 		in, out := &in.LabelFilter, &out.LabelFilter
-		*out = new(v1.LabelSelector)
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -142,6 +286,49 @@ func (in *ExternalDNSCRDSourceOptions) DeepCopy() *ExternalDNSCRDSourceOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSDeletionGuard) DeepCopyInto(out *ExternalDNSDeletionGuard) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSDeletionGuard.
+func (in *ExternalDNSDeletionGuard) DeepCopy() *ExternalDNSDeletionGuard {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSDeletionGuard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSDeletionGuardStatus) DeepCopyInto(out *ExternalDNSDeletionGuardStatus) {
+	*out = *in
+	if in.BlockedContainers != nil {
+		in, out := &in.BlockedContainers, &out.BlockedContainers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AcknowledgedTime != nil {
+		in, out := &in.AcknowledgedTime, &out.AcknowledgedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PlannedTime != nil {
+		in, out := &in.PlannedTime, &out.PlannedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSDeletionGuardStatus.
+func (in *ExternalDNSDeletionGuardStatus) DeepCopy() *ExternalDNSDeletionGuardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSDeletionGuardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSDomain) DeepCopyInto(out *ExternalDNSDomain) {
 	*out = *in
@@ -183,6 +370,36 @@ func (in *ExternalDNSDomainUnion) DeepCopy() *ExternalDNSDomainUnion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSDryRunStatus) DeepCopyInto(out *ExternalDNSDryRunStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSDryRunStatus.
+func (in *ExternalDNSDryRunStatus) DeepCopy() *ExternalDNSDryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSDryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSDynamoDBRegistryOptions) DeepCopyInto(out *ExternalDNSDynamoDBRegistryOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSDynamoDBRegistryOptions.
+func (in *ExternalDNSDynamoDBRegistryOptions) DeepCopy() *ExternalDNSDynamoDBRegistryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSDynamoDBRegistryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSGCPProviderOptions) DeepCopyInto(out *ExternalDNSGCPProviderOptions) {
 	*out = *in
@@ -208,6 +425,11 @@ func (in *ExternalDNSGCPProviderOptions) DeepCopy() *ExternalDNSGCPProviderOptio
 func (in *ExternalDNSInfobloxProviderOptions) DeepCopyInto(out *ExternalDNSInfobloxProviderOptions) {
 	*out = *in
 	out.Credentials = in.Credentials
+	if in.GridCA != nil {
+		in, out := &in.GridCA, &out.GridCA
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSInfobloxProviderOptions.
@@ -252,6 +474,41 @@ func (in *ExternalDNSList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSLogging) DeepCopyInto(out *ExternalDNSLogging) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSLogging.
+func (in *ExternalDNSLogging) DeepCopy() *ExternalDNSLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSMetrics) DeepCopyInto(out *ExternalDNSMetrics) {
+	*out = *in
+	if in.ScrapeInterval != nil {
+		in, out := &in.ScrapeInterval, &out.ScrapeInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSMetrics.
+func (in *ExternalDNSMetrics) DeepCopy() *ExternalDNSMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSOpenShiftRouteOptions) DeepCopyInto(out *ExternalDNSOpenShiftRouteOptions) {
 	*out = *in
@@ -267,6 +524,141 @@ func (in *ExternalDNSOpenShiftRouteOptions) DeepCopy() *ExternalDNSOpenShiftRout
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSOperand) DeepCopyInto(out *ExternalDNSOperand) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSOperand.
+func (in *ExternalDNSOperand) DeepCopy() *ExternalDNSOperand {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSOperand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSOperandDeployment) DeepCopyInto(out *ExternalDNSOperandDeployment) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(ExternalDNSOperandProbes)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSOperandDeployment.
+func (in *ExternalDNSOperandDeployment) DeepCopy() *ExternalDNSOperandDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSOperandDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSOperandMetadata) DeepCopyInto(out *ExternalDNSOperandMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSOperandMetadata.
+func (in *ExternalDNSOperandMetadata) DeepCopy() *ExternalDNSOperandMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSOperandMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSOperandProbes) DeepCopyInto(out *ExternalDNSOperandProbes) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSOperandProbes.
+func (in *ExternalDNSOperandProbes) DeepCopy() *ExternalDNSOperandProbes {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSOperandProbes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSProvider) DeepCopyInto(out *ExternalDNSProvider) {
 	*out = *in
@@ -288,12 +680,12 @@ func (in *ExternalDNSProvider) DeepCopyInto(out *ExternalDNSProvider) {
 	if in.BlueCat != nil {
 		in, out := &in.BlueCat, &out.BlueCat
 		*out = new(ExternalDNSBlueCatProviderOptions)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Infoblox != nil {
 		in, out := &in.Infoblox, &out.Infoblox
 		*out = new(ExternalDNSInfobloxProviderOptions)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -307,12 +699,74 @@ func (in *ExternalDNSProvider) DeepCopy() *ExternalDNSProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSProviderTarget) DeepCopyInto(out *ExternalDNSProviderTarget) {
+	*out = *in
+	in.ExternalDNSProvider.DeepCopyInto(&out.ExternalDNSProvider)
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSProviderTarget.
+func (in *ExternalDNSProviderTarget) DeepCopy() *ExternalDNSProviderTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSProviderTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSRegistry) DeepCopyInto(out *ExternalDNSRegistry) {
+	*out = *in
+	if in.DynamoDB != nil {
+		in, out := &in.DynamoDB, &out.DynamoDB
+		*out = new(ExternalDNSDynamoDBRegistryOptions)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(ExternalDNSRegistryEncryption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSRegistry.
+func (in *ExternalDNSRegistry) DeepCopy() *ExternalDNSRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSRegistryEncryption) DeepCopyInto(out *ExternalDNSRegistryEncryption) {
+	*out = *in
+	out.Key = in.Key
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSRegistryEncryption.
+func (in *ExternalDNSRegistryEncryption) DeepCopy() *ExternalDNSRegistryEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSRegistryEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSServiceSourceOptions) DeepCopyInto(out *ExternalDNSServiceSourceOptions) {
 	*out = *in
 	if in.ServiceType != nil {
 		in, out := &in.ServiceType, &out.ServiceType
-		*out = make([]corev1.ServiceType, len(*in))
+		*out = make([]v1.ServiceType, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -353,7 +807,7 @@ func (in *ExternalDNSSourceUnion) DeepCopyInto(out *ExternalDNSSourceUnion) {
 	*out = *in
 	if in.LabelFilter != nil {
 		in, out := &in.LabelFilter, &out.LabelFilter
-		*out = new(v1.LabelSelector)
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Service != nil {
@@ -389,12 +843,64 @@ func (in *ExternalDNSSpec) DeepCopyInto(out *ExternalDNSSpec) {
 		}
 	}
 	in.Provider.DeepCopyInto(&out.Provider)
+	if in.AdditionalProviders != nil {
+		in, out := &in.AdditionalProviders, &out.AdditionalProviders
+		*out = make([]ExternalDNSProviderTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Source.DeepCopyInto(&out.Source)
 	if in.Zones != nil {
 		in, out := &in.Zones, &out.Zones
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Operand != nil {
+		in, out := &in.Operand, &out.Operand
+		*out = new(ExternalDNSOperand)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(ExternalDNSLogging)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(ExternalDNSMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperandMetadata != nil {
+		in, out := &in.OperandMetadata, &out.OperandMetadata
+		*out = new(ExternalDNSOperandMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperandDeployment != nil {
+		in, out := &in.OperandDeployment, &out.OperandDeployment
+		*out = new(ExternalDNSOperandDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnsupportedOperandOverrides != nil {
+		in, out := &in.UnsupportedOperandOverrides, &out.UnsupportedOperandOverrides
+		*out = new(ExternalDNSUnsupportedOperandOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionGuard != nil {
+		in, out := &in.DeletionGuard, &out.DeletionGuard
+		*out = new(ExternalDNSDeletionGuard)
+		**out = **in
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(ExternalDNSSyncOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(ExternalDNSRegistry)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSSpec.
@@ -412,7 +918,7 @@ func (in *ExternalDNSStatus) DeepCopyInto(out *ExternalDNSStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -422,6 +928,23 @@ func (in *ExternalDNSStatus) DeepCopyInto(out *ExternalDNSStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(ExternalDNSDryRunStatus)
+		**out = **in
+	}
+	if in.DeletionGuard != nil {
+		in, out := &in.DeletionGuard, &out.DeletionGuard
+		*out = new(ExternalDNSDeletionGuardStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneDeployments != nil {
+		in, out := &in.ZoneDeployments, &out.ZoneDeployments
+		*out = make([]ExternalDNSZoneDeploymentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSStatus.
@@ -434,6 +957,85 @@ func (in *ExternalDNSStatus) DeepCopy() *ExternalDNSStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSSyncOptions) DeepCopyInto(out *ExternalDNSSyncOptions) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MinEventSyncInterval != nil {
+		in, out := &in.MinEventSyncInterval, &out.MinEventSyncInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MinTTL != nil {
+		in, out := &in.MinTTL, &out.MinTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSSyncOptions.
+func (in *ExternalDNSSyncOptions) DeepCopy() *ExternalDNSSyncOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSSyncOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSUnsupportedOperandOverrides) DeepCopyInto(out *ExternalDNSUnsupportedOperandOverrides) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSUnsupportedOperandOverrides.
+func (in *ExternalDNSUnsupportedOperandOverrides) DeepCopy() *ExternalDNSUnsupportedOperandOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSUnsupportedOperandOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSZoneDeploymentStatus) DeepCopyInto(out *ExternalDNSZoneDeploymentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSZoneDeploymentStatus.
+func (in *ExternalDNSZoneDeploymentStatus) DeepCopy() *ExternalDNSZoneDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSZoneDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
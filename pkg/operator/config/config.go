@@ -35,6 +35,7 @@ import (
 
 const (
 	DefaultExternalDNSImage        = "quay.io/external-dns-operator/external-dns:latest"
+	DefaultKubeRBACProxyImage      = "quay.io/openshift/origin-kube-rbac-proxy:latest"
 	DefaultMetricsAddr             = "127.0.0.1:8080"
 	DefaultOperatorNamespace       = "external-dns-operator"
 	DefaultOperandNamespace        = "external-dns"
@@ -59,6 +60,10 @@ type Config struct {
 	// by the operator.
 	ExternalDNSImage string
 
+	// KubeRBACProxyImage is the image of the kube-rbac-proxy sidecars
+	// serving the health and metrics endpoints of the ExternalDNS containers.
+	KubeRBACProxyImage string
+
 	// MetricsBindAddress is the TCP address that the operator should bind to for
 	// serving prometheus metrics. It can be set to "0" to disable the metrics serving.
 	MetricsBindAddress string
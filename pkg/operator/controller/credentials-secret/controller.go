@@ -19,6 +19,7 @@ package credentials_secret
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -97,11 +98,7 @@ func New(mgr manager.Manager, config Config) (controller.Controller, error) {
 					return hasSecret(e.Object, config.IsOpenShift)
 				},
 				UpdateFunc: func(e event.UpdateEvent) bool {
-					oldED := e.ObjectOld.(*operatorv1beta1.ExternalDNS)
-					newED := e.ObjectNew.(*operatorv1beta1.ExternalDNS)
-					oldName := getExternalDNSCredentialsSecretName(oldED, config.IsOpenShift)
-					newName := getExternalDNSCredentialsSecretName(newED, config.IsOpenShift)
-					return oldName != newName || oldED.DeletionTimestamp != newED.DeletionTimestamp
+					return credentialsInputsChanged(e.ObjectOld, e.ObjectNew, config.IsOpenShift)
 				},
 				GenericFunc: func(e event.GenericEvent) bool {
 					return hasSecret(e.Object, config.IsOpenShift)
@@ -119,11 +116,7 @@ func New(mgr manager.Manager, config Config) (controller.Controller, error) {
 		credentialsSecretIndexFieldName,
 		client.IndexerFunc(func(o client.Object) []string {
 			ed := o.(*operatorv1beta1.ExternalDNS)
-			name := getExternalDNSCredentialsSecretName(ed, config.IsOpenShift)
-			if len(name) == 0 {
-				return []string{}
-			}
-			return []string{name}
+			return getExternalDNSCredentialsSecretNames(ed, config.IsOpenShift)
 		}),
 	); err != nil {
 		return nil, fmt.Errorf("failed to create index for credentials secret: %w", err)
@@ -156,11 +149,11 @@ func New(mgr manager.Manager, config Config) (controller.Controller, error) {
 		credentialsSecretIndexFieldNameInOperand,
 		client.IndexerFunc(func(o client.Object) []string {
 			ed := o.(*operatorv1beta1.ExternalDNS)
-			name := extdnscontroller.ExternalDNSDestCredentialsSecretName("", ed.Name).Name
-			if len(name) == 0 {
-				return []string{}
+			names := []string{extdnscontroller.ExternalDNSDestCredentialsSecretName("", ed.Name).Name}
+			for _, target := range ed.Spec.AdditionalProviders {
+				names = append(names, extdnscontroller.ExternalDNSDestProviderCredentialsSecretName("", ed.Name, target.Type).Name)
 			}
-			return []string{name}
+			return names
 		}),
 	); err != nil {
 		return nil, fmt.Errorf("failed to create index for credentials secret: %w", err)
@@ -234,10 +227,28 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		Name:      srcSecretNameOnly,
 	}
 
-	if _, _, err := r.ensureCredentialsSecret(ctx, srcSecretName, extDNS, fromCR); err != nil {
+	destSecretName := extdnscontroller.ExternalDNSDestCredentialsSecretName(r.config.TargetNamespace, extDNS.Name)
+
+	if _, _, err := r.ensureCredentialsSecret(ctx, srcSecretName, destSecretName, extDNS, fromCR); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to ensure credentials secret for externalDNS %q: %w", extDNS.Name, err)
 	}
 
+	// additional providers always have the credentials secret specified explicitly
+	for _, target := range extDNS.Spec.AdditionalProviders {
+		targetExtDNS := operatorutils.ExternalDNSForProviderTarget(extDNS, target)
+		if !operatorutils.CredentialsRequiredProvider(targetExtDNS) {
+			continue
+		}
+		srcSecretName := types.NamespacedName{
+			Namespace: r.config.SourceNamespace,
+			Name:      extdnscontroller.ExternalDNSCredentialsSecretNameFromProvider(targetExtDNS),
+		}
+		destSecretName := extdnscontroller.ExternalDNSDestProviderCredentialsSecretName(r.config.TargetNamespace, extDNS.Name, target.Type)
+		if _, _, err := r.ensureCredentialsSecret(ctx, srcSecretName, destSecretName, targetExtDNS, true); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to ensure credentials secret of %q provider for externalDNS %q: %w", target.Type, extDNS.Name, err)
+		}
+	}
+
 	reqLogger.Info("credentials secret is reconciled for externalDNS instance")
 
 	return reconcile.Result{}, nil
@@ -246,7 +257,38 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 // hasSecret returns true if ExternalDNS references a secret
 func hasSecret(o client.Object, isOpenShift bool) bool {
 	ed := o.(*operatorv1beta1.ExternalDNS)
-	return len(getExternalDNSCredentialsSecretName(ed, isOpenShift)) != 0
+	return len(getExternalDNSCredentialsSecretNames(ed, isOpenShift)) != 0
+}
+
+// credentialsInputsChanged returns true if the update of ExternalDNS
+// affects the contents of the copied credentials secrets:
+// the source secret names, the provider options (e.g. the BlueCat config),
+// the operand metadata or the deletion.
+func credentialsInputsChanged(oldObj, newObj client.Object, isOpenShift bool) bool {
+	oldED := oldObj.(*operatorv1beta1.ExternalDNS)
+	newED := newObj.(*operatorv1beta1.ExternalDNS)
+	oldNames := getExternalDNSCredentialsSecretNames(oldED, isOpenShift)
+	newNames := getExternalDNSCredentialsSecretNames(newED, isOpenShift)
+	return !reflect.DeepEqual(oldNames, newNames) ||
+		!reflect.DeepEqual(oldED.Spec.Provider, newED.Spec.Provider) ||
+		!reflect.DeepEqual(oldED.Spec.AdditionalProviders, newED.Spec.AdditionalProviders) ||
+		!reflect.DeepEqual(oldED.Spec.OperandMetadata, newED.Spec.OperandMetadata) ||
+		oldED.DeletionTimestamp != newED.DeletionTimestamp
+}
+
+// getExternalDNSCredentialsSecretNames returns the names of the credentials secrets
+// of all the providers which should be used as source
+func getExternalDNSCredentialsSecretNames(externalDNS *operatorv1beta1.ExternalDNS, isOpenShift bool) []string {
+	names := []string{}
+	if name := getExternalDNSCredentialsSecretName(externalDNS, isOpenShift); name != "" {
+		names = append(names, name)
+	}
+	for _, target := range externalDNS.Spec.AdditionalProviders {
+		if name := extdnscontroller.ExternalDNSCredentialsSecretNameFromProvider(operatorutils.ExternalDNSForProviderTarget(externalDNS, target)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
 // getExternalDNSCredentialsSecretName returns the name of the credentials secret which should be used as source
@@ -49,6 +49,7 @@ const (
 	testSrcSecretName        = "testsecret"
 	testTargetSecretName     = "external-dns-credentials-test"
 	testSrcSecretNameWhenOCP = "externaldns-cloud-credentials"
+	testAdditionalSrcSecret  = "azuresecret"
 )
 
 func TestReconcile(t *testing.T) {
@@ -107,6 +108,23 @@ func TestReconcile(t *testing.T) {
 			inputRequest:    testRequest(),
 			expectedResult:  reconcile.Result{},
 		},
+		{
+			name:            "Operand metadata of existing instance changed",
+			existingObjects: []runtime.Object{testAWSExtDNSInstanceWithOperandMetadata(), testSrcSecret(), testTargetSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Modified,
+					ObjType:   "secret",
+					NamespacedName: types.NamespacedName{
+						Namespace: testOperandNamespace,
+						Name:      testTargetSecretName,
+					},
+				},
+			},
+		},
 		{
 			name:            "Target secret doesn't have credentials key",
 			existingObjects: []runtime.Object{testAWSExtDNSInstance(), testSrcSecret(), testTargetSecretWithoutCredentialsKey()},
@@ -191,6 +209,31 @@ func TestReconcile(t *testing.T) {
 			expectedResult:  reconcile.Result{},
 			errExpected:     true,
 		},
+		{
+			name:            "Structured Bluecat config is generated",
+			existingObjects: []runtime.Object{testBlueCatStructuredExtDNSInstance(), testBlueCatCredentialsSrcSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   "secret",
+					NamespacedName: types.NamespacedName{
+						Namespace: testOperandNamespace,
+						Name:      testTargetSecretName,
+					},
+				},
+			},
+		},
+		{
+			name:            "Structured Bluecat config with incomplete credentials",
+			existingObjects: []runtime.Object{testBlueCatStructuredExtDNSInstance(), testBlueCatSrcSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			errExpected:     true,
+		},
 		{
 			name: "Bootstrap when platform is OCP and it provided the credentials secret",
 			// externaldns without credentials specified + secret provided by OCP
@@ -227,6 +270,48 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:            "Additional provider secrets are copied",
+			existingObjects: []runtime.Object{testAWSExtDNSInstanceWithAdditionalProviders(), testSrcSecret(), testAzureAdditionalSrcSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   "secret",
+					NamespacedName: types.NamespacedName{
+						Namespace: testOperandNamespace,
+						Name:      testTargetSecretName,
+					},
+				},
+				{
+					EventType: watch.Added,
+					ObjType:   "secret",
+					NamespacedName: types.NamespacedName{
+						Namespace: testOperandNamespace,
+						Name:      testTargetSecretName + "-azure",
+					},
+				},
+			},
+		},
+		{
+			name:            "Additional provider source secret doesn't exist yet",
+			existingObjects: []runtime.Object{testAWSExtDNSInstanceWithAdditionalProviders(), testSrcSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   "secret",
+					NamespacedName: types.NamespacedName{
+						Namespace: testOperandNamespace,
+						Name:      testTargetSecretName,
+					},
+				},
+			},
+		},
 		{
 			name:            "Deleted ExternalDNS",
 			existingObjects: []runtime.Object{},
@@ -284,6 +369,20 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestDesiredBlueCatCredentialsSecret(t *testing.T) {
+	destName := types.NamespacedName{Namespace: testOperandNamespace, Name: testTargetSecretName}
+	got, err := desiredCredentialsSecret(testBlueCatCredentialsSrcSecret(), destName, testBlueCatStructuredExtDNSInstance(), false, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string][]byte{
+		"bluecat.json": []byte(`{"gatewayHost":"https://bluecatgw.example.com","gatewayUsername":"user","gatewayPassword":"pass","dnsConfiguration":"Example","dnsServerName":"ns1.example.com","dnsDeployType":"quick-deploy","dnsView":"Internal","rootZone":"example.com","skipTLSVerify":false}`),
+	}
+	if diff := cmp.Diff(expected, got.Data); diff != "" {
+		t.Errorf("unexpected secret data (-want +got):\n%s", diff)
+	}
+}
+
 func TestGetExternalDNSCredentialsSecretName(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -311,6 +410,11 @@ func TestGetExternalDNSCredentialsSecretName(t *testing.T) {
 			inputExtDNS: testBlueCatExtDNSInstance(),
 			expected:    testSrcSecretName,
 		},
+		{
+			name:        "BlueCat structured configuration",
+			inputExtDNS: testBlueCatStructuredExtDNSInstance(),
+			expected:    testSrcSecretName,
+		},
 		{
 			name:        "Infoblox",
 			inputExtDNS: testInfobloxExtDNSInstance(),
@@ -392,6 +496,11 @@ func TestHasSecret(t *testing.T) {
 			inputObject: testAWSExtDNSInstanceNoSecret(),
 			expected:    false,
 		},
+		{
+			name:        "Secret of additional provider",
+			inputObject: testAzureAdditionalProviderOnlyExtDNSInstance(),
+			expected:    true,
+		},
 		{
 			name:             "Default secret for OpenShift",
 			inputObject:      testAWSExtDNSInstanceNoSecret(),
@@ -409,6 +518,68 @@ func TestHasSecret(t *testing.T) {
 	}
 }
 
+func TestCredentialsInputsChanged(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mutate   func(*operatorv1beta1.ExternalDNS)
+		expected bool
+	}{
+		{
+			name:     "No change",
+			mutate:   func(*operatorv1beta1.ExternalDNS) {},
+			expected: false,
+		},
+		{
+			name: "Credentials secret name changed",
+			mutate: func(ed *operatorv1beta1.ExternalDNS) {
+				ed.Spec.Provider.BlueCat.Credentials.Name = "othersecret"
+			},
+			expected: true,
+		},
+		{
+			name: "BlueCat gateway host changed",
+			mutate: func(ed *operatorv1beta1.ExternalDNS) {
+				ed.Spec.Provider.BlueCat.GatewayHost = "https://othergw.example.com"
+			},
+			expected: true,
+		},
+		{
+			name: "Additional provider added",
+			mutate: func(ed *operatorv1beta1.ExternalDNS) {
+				ed.Spec.AdditionalProviders = testAWSExtDNSInstanceWithAdditionalProviders().Spec.AdditionalProviders
+			},
+			expected: true,
+		},
+		{
+			name: "Operand metadata changed",
+			mutate: func(ed *operatorv1beta1.ExternalDNS) {
+				ed.Spec.OperandMetadata = &operatorv1beta1.ExternalDNSOperandMetadata{
+					Labels: map[string]string{"team": "dns"},
+				}
+			},
+			expected: true,
+		},
+		{
+			name: "Unrelated spec field changed",
+			mutate: func(ed *operatorv1beta1.ExternalDNS) {
+				ed.Spec.Zones = []string{"private-zone"}
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldED := testBlueCatStructuredExtDNSInstance()
+			newED := oldED.DeepCopy()
+			tc.mutate(newED)
+			if got := credentialsInputsChanged(oldED, newED, false); got != tc.expected {
+				t.Errorf("unexpected return value received. expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
 func testConfig() Config {
 	return Config{
 		SourceNamespace: testOperatorNamespace,
@@ -483,6 +654,15 @@ func testAWSExtDNSInstance() *operatorv1beta1.ExternalDNS {
 	return extDNS
 }
 
+func testAWSExtDNSInstanceWithOperandMetadata() *operatorv1beta1.ExternalDNS {
+	extDNS := testAWSExtDNSInstance()
+	extDNS.Spec.OperandMetadata = &operatorv1beta1.ExternalDNSOperandMetadata{
+		Labels:      map[string]string{"team": "dns"},
+		Annotations: map[string]string{"owner": "dns-team"},
+	}
+	return extDNS
+}
+
 func testAWSExtDNSInstanceRouteSource() *operatorv1beta1.ExternalDNS {
 	extDNS := testExtDNSInstanceforOCPRouteSource()
 	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
@@ -512,6 +692,38 @@ func testAWSExtDNSInstanceNoSecret() *operatorv1beta1.ExternalDNS {
 	return extDNS
 }
 
+func testAWSExtDNSInstanceWithAdditionalProviders() *operatorv1beta1.ExternalDNS {
+	extDNS := testAWSExtDNSInstance()
+	extDNS.Spec.AdditionalProviders = []operatorv1beta1.ExternalDNSProviderTarget{
+		{
+			ExternalDNSProvider: operatorv1beta1.ExternalDNSProvider{
+				Type: operatorv1beta1.ProviderTypeAzure,
+				Azure: &operatorv1beta1.ExternalDNSAzureProviderOptions{
+					ConfigFile: operatorv1beta1.SecretReference{
+						Name: testAdditionalSrcSecret,
+					},
+				},
+			},
+			Zones: []string{"azure-zone"},
+		},
+		{
+			ExternalDNSProvider: operatorv1beta1.ExternalDNSProvider{
+				Type: operatorv1beta1.ProviderTypeInMemory,
+			},
+			Zones: []string{"example.com"},
+		},
+	}
+	return extDNS
+}
+
+func testAzureAdditionalProviderOnlyExtDNSInstance() *operatorv1beta1.ExternalDNS {
+	extDNS := testAWSExtDNSInstanceWithAdditionalProviders()
+	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeInMemory,
+	}
+	return extDNS
+}
+
 // Azure
 func testAzureExtDNSInstance() *operatorv1beta1.ExternalDNS {
 	extDNS := testExtDNSInstance()
@@ -546,6 +758,12 @@ func testAzureSrcSecret() *corev1.Secret {
 	}
 }
 
+func testAzureAdditionalSrcSecret() *corev1.Secret {
+	secret := testAzureSrcSecret()
+	secret.Name = testAdditionalSrcSecret
+	return secret
+}
+
 func testAzureWrongSrcSecret() *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -596,6 +814,38 @@ func testBlueCatSrcSecret() *corev1.Secret {
 	}
 }
 
+func testBlueCatStructuredExtDNSInstance() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstance()
+	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeBlueCat,
+		BlueCat: &operatorv1beta1.ExternalDNSBlueCatProviderOptions{
+			Credentials: &operatorv1beta1.SecretReference{
+				Name: testSrcSecretName,
+			},
+			GatewayHost:      "https://bluecatgw.example.com",
+			DNSConfiguration: "Example",
+			DNSView:          "Internal",
+			RootZone:         "example.com",
+			DNSServerName:    "ns1.example.com",
+			DeployOnChange:   true,
+		},
+	}
+	return extDNS
+}
+
+func testBlueCatCredentialsSrcSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSrcSecretName,
+			Namespace: testOperatorNamespace,
+		},
+		Data: map[string][]byte{
+			"gatewayUsername": []byte("user"),
+			"gatewayPassword": []byte("pass"),
+		},
+	}
+}
+
 func testBlueCatWrongSecret() *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -31,12 +31,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
-	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	operatorutils "github.com/openshift/external-dns-operator/pkg/utils"
 )
 
-// ensureCredentialsSecret ensures that the source secret has been copied to the operand namespace.
+const (
+	blueCatConfigKey          = "bluecat.json"
+	blueCatGatewayUsernameKey = "gatewayUsername"
+	blueCatGatewayPasswordKey = "gatewayPassword"
+	blueCatNoDeploy           = "no-deploy"
+	blueCatQuickDeploy        = "quick-deploy"
+)
+
+// ensureCredentialsSecret ensures that the source secret has been copied to the destination secret in the operand namespace.
 // Returns the destination secret, a boolean if the destination secret exists, and an error when relevant.
-func (r *reconciler) ensureCredentialsSecret(ctx context.Context, sourceName types.NamespacedName, extDNS *operatorv1beta1.ExternalDNS, fromCR bool) (bool, *corev1.Secret, error) {
+func (r *reconciler) ensureCredentialsSecret(ctx context.Context, sourceName, destName types.NamespacedName, extDNS *operatorv1beta1.ExternalDNS, fromCR bool) (bool, *corev1.Secret, error) {
 	// get the source secret
 	sourceExists, source, err := r.currentCredentialsSecret(ctx, sourceName)
 	if err != nil {
@@ -45,12 +53,12 @@ func (r *reconciler) ensureCredentialsSecret(ctx context.Context, sourceName typ
 		return false, nil, nil
 	}
 
-	destName := controller.ExternalDNSDestCredentialsSecretName(r.config.TargetNamespace, extDNS.Name)
 	// desired is created from source
 	desired, err := desiredCredentialsSecret(source, destName, extDNS, r.config.IsOpenShift, fromCR)
 	if err != nil {
 		return false, nil, err
 	}
+	operatorutils.ApplyOperandMetadata(extDNS, &desired.ObjectMeta)
 
 	if err := controllerutil.SetControllerReference(extDNS, desired, r.scheme); err != nil {
 		return false, nil, fmt.Errorf("failed to set the controller reference for credentials secret: %w", err)
@@ -164,7 +172,14 @@ func desiredCredentialsSecret(sourceSecret *corev1.Secret, destName types.Namesp
 			return nil, fmt.Errorf("invalid credentials for GCP")
 		}
 	case operatorv1beta1.ProviderTypeBlueCat:
-		if config, exists := sourceSecret.Data["bluecat.json"]; !exists || len(config) == 0 {
+		if extDNS.Spec.Provider.BlueCat != nil && extDNS.Spec.Provider.BlueCat.Credentials != nil {
+			// generate the config file from the spec and the credentials
+			config, err := newBlueCatConfig(extDNS.Spec.Provider.BlueCat, sourceSecret)
+			if err != nil {
+				return nil, err
+			}
+			secret.Data = map[string][]byte{blueCatConfigKey: config}
+		} else if config, exists := sourceSecret.Data[blueCatConfigKey]; !exists || len(config) == 0 {
 			return nil, fmt.Errorf("invalid config for bluecat")
 		}
 	}
@@ -175,10 +190,11 @@ func desiredCredentialsSecret(sourceSecret *corev1.Secret, destName types.Namesp
 // updateCredentialsSecret updates the destination secret with the desired content if update is needed.
 // Returns a Boolean indicating whether the secret was updated, and an error value.
 func (r *reconciler) updateCredentialsSecret(ctx context.Context, current, desired *corev1.Secret) (bool, error) {
-	if secretsEqual(current, desired) {
+	updated := current.DeepCopy()
+	metadataChanged := operatorutils.OperandMetadataChanged(&current.ObjectMeta, &desired.ObjectMeta, &updated.ObjectMeta)
+	if !metadataChanged && secretsEqual(current, desired) {
 		return false, nil
 	}
-	updated := current.DeepCopy()
 	updated.Data = desired.Data
 	if err := r.client.Update(ctx, updated); err != nil {
 		return false, err
@@ -200,3 +216,45 @@ func newConfigForStaticCreds(accessKey string, accessSecret string) []byte {
 	fmt.Fprintf(buf, "aws_secret_access_key = %s", accessSecret)
 	return buf.Bytes()
 }
+
+// blueCatConfig is the configuration file expected by the BlueCat provider of ExternalDNS.
+type blueCatConfig struct {
+	GatewayHost      string `json:"gatewayHost"`
+	GatewayUsername  string `json:"gatewayUsername"`
+	GatewayPassword  string `json:"gatewayPassword"`
+	DNSConfiguration string `json:"dnsConfiguration"`
+	DNSServerName    string `json:"dnsServerName,omitempty"`
+	DNSDeployType    string `json:"dnsDeployType"`
+	DNSView          string `json:"dnsView"`
+	RootZone         string `json:"rootZone,omitempty"`
+	SkipTLSVerify    bool   `json:"skipTLSVerify"`
+}
+
+// newBlueCatConfig returns the BlueCat configuration file built from the given options
+// and the gateway credentials from the given secret.
+func newBlueCatConfig(opts *operatorv1beta1.ExternalDNSBlueCatProviderOptions, credsSecret *corev1.Secret) ([]byte, error) {
+	username, password := credsSecret.Data[blueCatGatewayUsernameKey], credsSecret.Data[blueCatGatewayPasswordKey]
+	if len(username) == 0 {
+		return nil, fmt.Errorf("invalid credentials for bluecat: %s not found", blueCatGatewayUsernameKey)
+	}
+	if len(password) == 0 {
+		return nil, fmt.Errorf("invalid credentials for bluecat: %s not found", blueCatGatewayPasswordKey)
+	}
+
+	deployType := blueCatNoDeploy
+	if opts.DeployOnChange {
+		deployType = blueCatQuickDeploy
+	}
+
+	return json.Marshal(blueCatConfig{
+		GatewayHost:      opts.GatewayHost,
+		GatewayUsername:  string(username),
+		GatewayPassword:  string(password),
+		DNSConfiguration: opts.DNSConfiguration,
+		DNSServerName:    opts.DNSServerName,
+		DNSDeployType:    deployType,
+		DNSView:          opts.DNSView,
+		RootZone:         opts.RootZone,
+		SkipTLSVerify:    opts.SkipTLSVerify,
+	})
+}
@@ -0,0 +1,418 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+const (
+	// changeRequestExternalDNSLabel is the label of the change requests and apply jobs
+	// with the name of the ExternalDNS instance which planned the changes.
+	changeRequestExternalDNSLabel = "externaldns.olm.openshift.io/externaldns"
+	// changeRequestLabel is the label of the plan and apply job pods with the name of the change request.
+	changeRequestLabel = "externaldns.olm.openshift.io/dns-change-request"
+	// changeRequestJobLabel is the label of the change request job pods with the role of the job.
+	changeRequestJobLabel     = "externaldns.olm.openshift.io/dns-change-request-job"
+	changeRequestPlanJobRole  = "plan"
+	changeRequestApplyJobRole = "apply"
+
+	domainFilterArgPrefix      = "--domain-filter="
+	regexDomainFilterArgPrefix = "--regex-domain-filter="
+)
+
+// ensureExternalDNSChangeRequests ensures that the changes planned by the operand are waiting for the approval
+// in a change request and that the approved requests are applied if the plan didn't drift since.
+// The approved changes are planned again by a one-shot dry run right before they are applied,
+// both runs being limited to the approved records, and the apply run is started only if this plan matches.
+func (r *reconciler) ensureExternalDNSChangeRequests(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, deployments []*appsv1.Deployment) error {
+	if externalDNS.Spec.ChangeApproval != operatorv1beta1.ChangeApprovalManual {
+		return nil
+	}
+
+	plan, collected, err := r.collectDryRunPlan(ctx, deployments)
+	if err != nil {
+		return err
+	}
+	if !collected {
+		// the drift cannot be checked without the current plan
+		return nil
+	}
+	// the pending requests are neither drifted nor created from an incomplete plan
+	planRecognized := len(plan.unrecognizedContainers) == 0
+	if !planRecognized {
+		r.recorder.Eventf(externalDNS, corev1.EventTypeWarning, dryRunUnrecognizedEventReason, "The planned changes of containers %s could not be recognized in their logs, no change requests are created from them", strings.Join(plan.unrecognizedContainers, ", "))
+	}
+	changes := plan.changes()
+
+	requests := &operatorv1beta1.DNSChangeRequestList{}
+	if err := r.client.List(ctx, requests, client.InNamespace(r.config.Namespace), client.MatchingLabels{changeRequestExternalDNSLabel: externalDNS.Name}); err != nil {
+		return fmt.Errorf("failed to list DNS change requests: %w", err)
+	}
+
+	// a new request is created only if no request covers the current plan
+	planCovered := false
+	for i := range requests.Items {
+		req := &requests.Items[i]
+		switch req.Status.Phase {
+		case "", operatorv1beta1.DNSChangeRequestPhasePending:
+			if !planRecognized {
+				planCovered = true
+				continue
+			}
+			if !dnsChangesEqual(req.Spec.Changes, changes) {
+				if err := r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseDrifted, "ExternalDNS planned different changes before the request was applied.", ""); err != nil {
+					return err
+				}
+				continue
+			}
+			planCovered = true
+			if !req.Spec.Approved {
+				if err := r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhasePending, "The changes are waiting for the approval.", ""); err != nil {
+					return err
+				}
+				continue
+			}
+			job, err := r.ensureDNSChangeRequestJob(ctx, externalDNS, req, deployments, changeRequestPlanJobRole)
+			if err != nil {
+				return err
+			}
+			if err := r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseApplying, "The approved changes are being planned again before they are applied.", job.Name); err != nil {
+				return err
+			}
+		case operatorv1beta1.DNSChangeRequestPhaseApplying:
+			planCovered = true
+			if err := r.checkDNSChangeRequestJobs(ctx, externalDNS, req, deployments); err != nil {
+				return err
+			}
+		case operatorv1beta1.DNSChangeRequestPhaseApplied, operatorv1beta1.DNSChangeRequestPhaseFailed:
+			// the logs of the operand still show the applied changes for a while
+			if req.Status.CompletionTime != nil && clock.Since(req.Status.CompletionTime.Time) < dryRunLogsPeriod {
+				planCovered = true
+			}
+		}
+	}
+
+	if planCovered || !planRecognized || dnsChangesEmpty(changes) {
+		return nil
+	}
+	return r.createDNSChangeRequest(ctx, externalDNS, changes)
+}
+
+// createDNSChangeRequest creates a pending change request with the given changes.
+func (r *reconciler) createDNSChangeRequest(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, changes operatorv1beta1.DNSChanges) error {
+	req := &operatorv1beta1.DNSChangeRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: controller.ExternalDNSResourceName(externalDNS) + "-",
+			Namespace:    r.config.Namespace,
+			Labels: map[string]string{
+				changeRequestExternalDNSLabel: externalDNS.Name,
+			},
+		},
+		Spec: operatorv1beta1.DNSChangeRequestSpec{
+			ExternalDNSName: externalDNS.Name,
+			Changes:         changes,
+		},
+	}
+	if err := controllerutil.SetControllerReference(externalDNS, req, r.scheme); err != nil {
+		return fmt.Errorf("failed to set the controller reference for DNS change request: %w", err)
+	}
+	if err := r.client.Create(ctx, req); err != nil {
+		return fmt.Errorf("failed to create DNS change request for externalDNS %s: %w", externalDNS.Name, err)
+	}
+	r.log.Info("created DNS change request", "namespace", req.Namespace, "name", req.Name)
+
+	return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhasePending, "The changes are waiting for the approval.", "")
+}
+
+// updateDNSChangeRequestStatus updates the status of the given change request if it differs from the given one.
+// The completion time is set once the request reaches a final phase.
+func (r *reconciler) updateDNSChangeRequestStatus(ctx context.Context, req *operatorv1beta1.DNSChangeRequest, phase operatorv1beta1.DNSChangeRequestPhase, message, jobName string) error {
+	if req.Status.Phase == phase && req.Status.Message == message && req.Status.JobName == jobName {
+		return nil
+	}
+
+	updated := req.DeepCopy()
+	updated.Status.Phase = phase
+	updated.Status.Message = message
+	updated.Status.JobName = jobName
+	switch phase {
+	case operatorv1beta1.DNSChangeRequestPhaseApplied, operatorv1beta1.DNSChangeRequestPhaseFailed, operatorv1beta1.DNSChangeRequestPhaseDrifted:
+		now := metav1.NewTime(clock.Now())
+		updated.Status.CompletionTime = &now
+	}
+	if err := r.client.Status().Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update the status of DNS change request %s/%s: %w", req.Namespace, req.Name, err)
+	}
+	r.log.Info("updated DNS change request", "namespace", req.Namespace, "name", req.Name, "phase", phase)
+	return nil
+}
+
+// ensureDNSChangeRequestJob ensures that the job with the given role of the given change request exists.
+// Returns the job and an error when relevant.
+func (r *reconciler) ensureDNSChangeRequestJob(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, req *operatorv1beta1.DNSChangeRequest, deployments []*appsv1.Deployment, role string) (*batchv1.Job, error) {
+	nsName := dnsChangeRequestJobName(req, role)
+
+	current := &batchv1.Job{}
+	if err := r.client.Get(ctx, nsName, current); err == nil {
+		return current, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get DNS change request %s job %s: %w", role, nsName, err)
+	}
+
+	desired := desiredDNSChangeRequestJob(externalDNS, req, deployments, role)
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return nil, fmt.Errorf("failed to set the controller reference for DNS change request %s job: %w", role, err)
+	}
+	if err := r.client.Create(ctx, desired); err != nil {
+		return nil, fmt.Errorf("failed to create DNS change request %s job %s: %w", role, nsName, err)
+	}
+	r.log.Info("created DNS change request job", "namespace", desired.Namespace, "name", desired.Name, "role", role)
+	return desired, nil
+}
+
+// checkDNSChangeRequestJobs moves the change request forward once its current job finished.
+// The request drifts if the plan job planned different changes than the approved ones, the apply job is run otherwise.
+// The request fails if the apply job failed or applied different changes than the approved ones.
+func (r *reconciler) checkDNSChangeRequestJobs(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, req *operatorv1beta1.DNSChangeRequest, deployments []*appsv1.Deployment) error {
+	applyJob, err := r.currentDNSChangeRequestJob(ctx, req, changeRequestApplyJobRole)
+	if err != nil {
+		return err
+	}
+	if applyJob != nil {
+		finished, failed, message := jobResult(applyJob)
+		switch {
+		case !finished:
+			return nil
+		case failed:
+			return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseFailed, fmt.Sprintf("The apply job failed: %s", message), applyJob.Name)
+		}
+		applied, err := r.collectDNSChangeRequestJobPlan(ctx, applyJob)
+		if err != nil {
+			return err
+		}
+		if len(applied.unrecognizedContainers) != 0 {
+			return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseFailed, fmt.Sprintf("The applied changes of containers %s could not be recognized in the logs of the apply job.", strings.Join(applied.unrecognizedContainers, ", ")), applyJob.Name)
+		}
+		if !dnsChangesEqual(req.Spec.Changes, applied.changes()) {
+			return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseFailed, fmt.Sprintf("The apply job applied different changes than the approved ones: %s.", formatDNSChanges(applied.changes())), applyJob.Name)
+		}
+		return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseApplied, "The approved changes were applied.", applyJob.Name)
+	}
+
+	planJob, err := r.currentDNSChangeRequestJob(ctx, req, changeRequestPlanJobRole)
+	if err != nil {
+		return err
+	}
+	if planJob == nil {
+		return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseFailed, "The plan job was not found.", req.Status.JobName)
+	}
+	finished, failed, message := jobResult(planJob)
+	switch {
+	case !finished:
+		return nil
+	case failed:
+		return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseFailed, fmt.Sprintf("The plan job failed: %s", message), planJob.Name)
+	}
+	planned, err := r.collectDNSChangeRequestJobPlan(ctx, planJob)
+	if err != nil {
+		return err
+	}
+	if len(planned.unrecognizedContainers) != 0 {
+		return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseFailed, fmt.Sprintf("The planned changes of containers %s could not be recognized in the logs of the plan job.", strings.Join(planned.unrecognizedContainers, ", ")), planJob.Name)
+	}
+	if !dnsChangesEqual(req.Spec.Changes, planned.changes()) {
+		return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseDrifted, fmt.Sprintf("ExternalDNS planned different changes than the approved ones right before they were applied: %s.", formatDNSChanges(planned.changes())), planJob.Name)
+	}
+
+	applyJob, err = r.ensureDNSChangeRequestJob(ctx, externalDNS, req, deployments, changeRequestApplyJobRole)
+	if err != nil {
+		return err
+	}
+	return r.updateDNSChangeRequestStatus(ctx, req, operatorv1beta1.DNSChangeRequestPhaseApplying, "The approved changes are being applied.", applyJob.Name)
+}
+
+// currentDNSChangeRequestJob returns the job with the given role of the given change request, nil if it doesn't exist.
+func (r *reconciler) currentDNSChangeRequestJob(ctx context.Context, req *operatorv1beta1.DNSChangeRequest, role string) (*batchv1.Job, error) {
+	nsName := dnsChangeRequestJobName(req, role)
+	job := &batchv1.Job{}
+	if err := r.client.Get(ctx, nsName, job); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get DNS change request %s job %s: %w", role, nsName, err)
+	}
+	return job, nil
+}
+
+// collectDNSChangeRequestJobPlan returns the changes logged by the succeeded pods of the given change request job.
+func (r *reconciler) collectDNSChangeRequestJobPlan(ctx context.Context, job *batchv1.Job) (*dryRunPlan, error) {
+	plans := map[string]*dryRunPlan{}
+	if err := r.collectPodsContainerPlans(ctx, job.Namespace, job.Spec.Template.Labels, corev1.PodSucceeded, 0, plans); err != nil {
+		return nil, err
+	}
+	return mergeDryRunPlans(plans), nil
+}
+
+// dnsChangeRequestJobName returns the namespaced name of the job with the given role of the given change request.
+func dnsChangeRequestJobName(req *operatorv1beta1.DNSChangeRequest, role string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: req.Namespace,
+		Name:      req.Name + "-" + role,
+	}
+}
+
+// desiredDNSChangeRequestJob returns the job with the given role which runs the operand containers once.
+// The plan job keeps the dry run mode of the operand, the apply job runs without it.
+// Both jobs are limited to the approved records by a domain filter which replaces the domain filters of the operand.
+// The pods of the job are labeled differently from the operand pods
+// not to be mistaken for them when the planned changes are collected.
+func desiredDNSChangeRequestJob(externalDNS *operatorv1beta1.ExternalDNS, req *operatorv1beta1.DNSChangeRequest, deployments []*appsv1.Deployment, role string) *batchv1.Job {
+	labels := map[string]string{
+		changeRequestExternalDNSLabel: externalDNS.Name,
+		changeRequestLabel:            req.Name,
+		changeRequestJobLabel:         role,
+	}
+	template := oneShotOperandTemplate(deployments, labels, func(arg string) (string, bool) {
+		switch {
+		case arg == dryRunArg:
+			return arg, role == changeRequestPlanJobRole
+		case strings.HasPrefix(arg, domainFilterArgPrefix), strings.HasPrefix(arg, regexDomainFilterArgPrefix):
+			// the approved records were planned within the domain filters of the operand
+			return arg, false
+		}
+		return arg, true
+	}, regexDomainFilterArgPrefix+dnsChangesDomainRegexp(req.Spec.Changes))
+
+	nsName := dnsChangeRequestJobName(req, role)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsName.Name,
+			Namespace: nsName.Namespace,
+			Labels: map[string]string{
+				changeRequestExternalDNSLabel: externalDNS.Name,
+				changeRequestLabel:            req.Name,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](0),
+			Template:     *template,
+		},
+	}
+}
+
+// dnsChangesDomainRegexp returns the regular expression which matches only the names of the records of the given changes
+// and their parent domains. The parent domains are needed for the zones of the records to be selected,
+// their own changes would make the plan job drift from the approved changes.
+func dnsChangesDomainRegexp(changes operatorv1beta1.DNSChanges) string {
+	domains := map[string]struct{}{}
+	for _, change := range append(append(append([]string{}, changes.Creates...), changes.Updates...), changes.Deletes...) {
+		// the change is the name of the record followed by its type
+		name := strings.ToLower(strings.TrimSuffix(strings.Fields(change)[0], "."))
+		for labels := strings.Split(name, "."); len(labels) > 0; labels = labels[1:] {
+			domains[strings.Join(labels, ".")] = struct{}{}
+		}
+	}
+	quoted := make([]string, 0, len(domains))
+	for _, domain := range sortedDryRunChanges(domains) {
+		quoted = append(quoted, regexp.QuoteMeta(domain))
+	}
+	return "^(?:" + strings.Join(quoted, "|") + ")$"
+}
+
+// formatDNSChanges returns the given changes in a human readable form.
+func formatDNSChanges(changes operatorv1beta1.DNSChanges) string {
+	return fmt.Sprintf("creates [%s], updates [%s], deletes [%s]", strings.Join(changes.Creates, ", "), strings.Join(changes.Updates, ", "), strings.Join(changes.Deletes, ", "))
+}
+
+// jobResult returns true if the given job finished, true if it failed,
+// and the message of the failure when relevant.
+func jobResult(job *batchv1.Job) (bool, bool, string) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return true, false, ""
+		case batchv1.JobFailed:
+			return true, true, cond.Message
+		}
+	}
+	return false, false, ""
+}
+
+// oneShotOperandTemplate returns the pod template of the operand deployments
+// which runs the operand containers of all the deployments once, the pods are labeled with the given labels.
+// Each argument of the containers is replaced by the result of the given function
+// and dropped if the function returns false, the given extra arguments are added to all the containers.
+func oneShotOperandTemplate(deployments []*appsv1.Deployment, labels map[string]string, mapArg func(arg string) (string, bool), extraArgs ...string) *corev1.PodTemplateSpec {
+	// the deployments of the zones share the pod template except for the containers
+	template := deployments[0].Spec.Template.DeepCopy()
+	for _, deployment := range deployments[1:] {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			template.Spec.Containers = append(template.Spec.Containers, *container.DeepCopy())
+		}
+	}
+	// the proxies never exit, the one-shot run doesn't need the endpoints
+	template.Spec.Containers = operandContainers(template.Spec.Containers)
+	template.Labels = labels
+	template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	for i := range template.Spec.Containers {
+		args := make([]string, 0, len(template.Spec.Containers[i].Args)+len(extraArgs)+1)
+		for _, arg := range template.Spec.Containers[i].Args {
+			if mapped, keep := mapArg(arg); keep {
+				args = append(args, mapped)
+			}
+		}
+		args = append(args, extraArgs...)
+		template.Spec.Containers[i].Args = append(args, onceArg)
+		// the one-shot run exits before the probes matter
+		template.Spec.Containers[i].LivenessProbe = nil
+		template.Spec.Containers[i].ReadinessProbe = nil
+	}
+	return template
+}
+
+// dnsChangesEqual returns true if the given changes are the same.
+func dnsChangesEqual(a, b operatorv1beta1.DNSChanges) bool {
+	return cmp.Equal(a, b, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+// dnsChangesEmpty returns true if no changes are planned.
+func dnsChangesEmpty(changes operatorv1beta1.DNSChanges) bool {
+	return len(changes.Creates) == 0 && len(changes.Updates) == 0 && len(changes.Deletes) == 0
+}
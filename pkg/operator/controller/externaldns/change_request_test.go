@@ -0,0 +1,429 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+const testChangeRequestName = "external-dns-test-abcde"
+
+// testChangeRequestSummary is the part of the change request checked by the tests.
+type testChangeRequestSummary struct {
+	Phase    operatorv1beta1.DNSChangeRequestPhase
+	Approved bool
+	Changes  operatorv1beta1.DNSChanges
+}
+
+func TestEnsureExternalDNSChangeRequests(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      test.OperandName,
+			Namespace: test.OperandNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					appNameLabel:     ExternalDNSBaseName,
+					appInstanceLabel: test.Name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						appNameLabel:     ExternalDNSBaseName,
+						appInstanceLabel: test.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "external-dns-zone1",
+							Image: test.OperandImage,
+							Args:  []string{"--provider=aws", "--domain-filter=test.com", "--dry-run"},
+						},
+						testMetricsProxyContainer(7979),
+					},
+				},
+			},
+		},
+	}
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDryRunPodName,
+			Namespace: test.OperandNamespace,
+			Labels: map[string]string{
+				appNameLabel:     ExternalDNSBaseName,
+				appInstanceLabel: test.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "external-dns-zone1"},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	podLogs := fakePodLogsReader{
+		testDryRunPodName + "/external-dns-zone1": `time="2024-05-14T10:00:01Z" level=info msg="Desired change: CREATE app.test.com A [Id: /hostedzone/zone1]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: DELETE old.test.com A [Id: /hostedzone/zone1]"`,
+	}
+	plannedChanges := operatorv1beta1.DNSChanges{
+		Creates: []string{"app.test.com A"},
+		Deletes: []string{"old.test.com A"},
+	}
+	driftedChanges := operatorv1beta1.DNSChanges{
+		Creates: []string{"app.test.com A"},
+		Updates: []string{"api.test.com CNAME"},
+	}
+	changeRequest := func(changes operatorv1beta1.DNSChanges, approved bool, phase operatorv1beta1.DNSChangeRequestPhase) *operatorv1beta1.DNSChangeRequest {
+		return &operatorv1beta1.DNSChangeRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testChangeRequestName,
+				Namespace: test.OperandNamespace,
+				Labels: map[string]string{
+					changeRequestExternalDNSLabel: test.Name,
+				},
+			},
+			Spec: operatorv1beta1.DNSChangeRequestSpec{
+				ExternalDNSName: test.Name,
+				Changes:         changes,
+				Approved:        approved,
+			},
+			Status: operatorv1beta1.DNSChangeRequestStatus{
+				Phase: phase,
+			},
+		}
+	}
+	job := func(role string, condType batchv1.JobConditionType) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testChangeRequestName + "-" + role,
+				Namespace: test.OperandNamespace,
+			},
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							changeRequestExternalDNSLabel: test.Name,
+							changeRequestLabel:            testChangeRequestName,
+							changeRequestJobLabel:         role,
+						},
+					},
+				},
+			},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{
+						Type:   condType,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+	jobPod := func(role string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testChangeRequestName + "-" + role + "-x2k4p",
+				Namespace: test.OperandNamespace,
+				Labels: map[string]string{
+					changeRequestExternalDNSLabel: test.Name,
+					changeRequestLabel:            testChangeRequestName,
+					changeRequestJobLabel:         role,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "external-dns-zone1"},
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+			},
+		}
+	}
+	// the logs of the operand and of the jobs with the given role
+	jobLogs := func(logs string, roles ...string) fakePodLogsReader {
+		reader := fakePodLogsReader{}
+		for key, value := range podLogs {
+			reader[key] = value
+		}
+		for _, role := range roles {
+			reader[testChangeRequestName+"-"+role+"-x2k4p/external-dns-zone1"] = logs
+		}
+		return reader
+	}
+	driftedLogs := `time="2024-05-14T10:00:01Z" level=info msg="Desired change: CREATE app.test.com A [Id: /hostedzone/zone1]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: UPSERT api.test.com CNAME [Id: /hostedzone/zone1]"`
+	unrecognizedLogs := `time="2024-05-14T10:00:00Z" level=info msg="Instantiating new Kubernetes client"`
+
+	testCases := []struct {
+		name             string
+		changeApproval   operatorv1beta1.ExternalDNSChangeApproval
+		existingObjects  []runtime.Object
+		podLogs          fakePodLogsReader
+		expectedRequests []testChangeRequestSummary
+		expectedJobsArgs map[string][]string
+	}{
+		{
+			name:            "Approval is not required",
+			changeApproval:  operatorv1beta1.ChangeApprovalNone,
+			existingObjects: []runtime.Object{runningPod},
+			podLogs:         podLogs,
+		},
+		{
+			name:            "No changes planned",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod},
+			podLogs: fakePodLogsReader{
+				testDryRunPodName + "/external-dns-zone1": `time="2024-05-14T10:00:01Z" level=info msg="All records are already up to date"`,
+			},
+		},
+		{
+			name:            "New plan",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod},
+			podLogs:         podLogs,
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhasePending, Changes: plannedChanges},
+			},
+		},
+		{
+			name:            "Plan is waiting for approval",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(plannedChanges, false, operatorv1beta1.DNSChangeRequestPhasePending)},
+			podLogs:         podLogs,
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhasePending, Changes: plannedChanges},
+			},
+		},
+		{
+			name:            "Plan is approved",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(plannedChanges, true, operatorv1beta1.DNSChangeRequestPhasePending)},
+			podLogs:         podLogs,
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhaseApplying, Approved: true, Changes: plannedChanges},
+			},
+			expectedJobsArgs: map[string][]string{
+				changeRequestPlanJobRole: {"--provider=aws", "--dry-run", `--regex-domain-filter=^(?:app\.test\.com|com|old\.test\.com|test\.com)$`, "--once"},
+			},
+		},
+		{
+			name:            "Plan is not recognized",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(driftedChanges, true, operatorv1beta1.DNSChangeRequestPhasePending)},
+			podLogs: fakePodLogsReader{
+				testDryRunPodName + "/external-dns-zone1": unrecognizedLogs,
+			},
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhasePending, Approved: true, Changes: driftedChanges},
+			},
+		},
+		{
+			name:            "Approved plan drifted",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(driftedChanges, true, operatorv1beta1.DNSChangeRequestPhasePending)},
+			podLogs:         podLogs,
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhaseDrifted, Approved: true, Changes: driftedChanges},
+				{Phase: operatorv1beta1.DNSChangeRequestPhasePending, Changes: plannedChanges},
+			},
+		},
+		{
+			name:            "Approved plan is planned again",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(plannedChanges, true, operatorv1beta1.DNSChangeRequestPhaseApplying), job(changeRequestPlanJobRole, batchv1.JobComplete), jobPod(changeRequestPlanJobRole)},
+			podLogs:         jobLogs(podLogs[testDryRunPodName+"/external-dns-zone1"], changeRequestPlanJobRole),
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhaseApplying, Approved: true, Changes: plannedChanges},
+			},
+			expectedJobsArgs: map[string][]string{
+				changeRequestApplyJobRole: {"--provider=aws", `--regex-domain-filter=^(?:app\.test\.com|com|old\.test\.com|test\.com)$`, "--once"},
+			},
+		},
+		{
+			name:            "Approved plan drifted right before the application",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(plannedChanges, true, operatorv1beta1.DNSChangeRequestPhaseApplying), job(changeRequestPlanJobRole, batchv1.JobComplete), jobPod(changeRequestPlanJobRole)},
+			podLogs:         jobLogs(driftedLogs, changeRequestPlanJobRole),
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhaseDrifted, Approved: true, Changes: plannedChanges},
+			},
+		},
+		{
+			name:            "Approved plan is not recognized right before the application",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(plannedChanges, true, operatorv1beta1.DNSChangeRequestPhaseApplying), job(changeRequestPlanJobRole, batchv1.JobComplete), jobPod(changeRequestPlanJobRole)},
+			podLogs:         jobLogs(unrecognizedLogs, changeRequestPlanJobRole),
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhaseFailed, Approved: true, Changes: plannedChanges},
+			},
+		},
+		{
+			name:            "Plan job failed",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(plannedChanges, true, operatorv1beta1.DNSChangeRequestPhaseApplying), job(changeRequestPlanJobRole, batchv1.JobFailed)},
+			podLogs:         podLogs,
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhaseFailed, Approved: true, Changes: plannedChanges},
+			},
+		},
+		{
+			name:            "Plan is applied",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(plannedChanges, true, operatorv1beta1.DNSChangeRequestPhaseApplying), job(changeRequestPlanJobRole, batchv1.JobComplete), job(changeRequestApplyJobRole, batchv1.JobComplete), jobPod(changeRequestApplyJobRole)},
+			podLogs:         jobLogs(podLogs[testDryRunPodName+"/external-dns-zone1"], changeRequestApplyJobRole),
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhaseApplied, Approved: true, Changes: plannedChanges},
+			},
+		},
+		{
+			name:            "Different changes are applied",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(plannedChanges, true, operatorv1beta1.DNSChangeRequestPhaseApplying), job(changeRequestPlanJobRole, batchv1.JobComplete), job(changeRequestApplyJobRole, batchv1.JobComplete), jobPod(changeRequestApplyJobRole)},
+			podLogs:         jobLogs(driftedLogs, changeRequestApplyJobRole),
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhaseFailed, Approved: true, Changes: plannedChanges},
+			},
+		},
+		{
+			name:            "Plan failed to apply",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{runningPod, changeRequest(plannedChanges, true, operatorv1beta1.DNSChangeRequestPhaseApplying), job(changeRequestPlanJobRole, batchv1.JobComplete), job(changeRequestApplyJobRole, batchv1.JobFailed)},
+			podLogs:         podLogs,
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhaseFailed, Approved: true, Changes: plannedChanges},
+			},
+		},
+		{
+			name:            "Operand is not running",
+			changeApproval:  operatorv1beta1.ChangeApprovalManual,
+			existingObjects: []runtime.Object{changeRequest(driftedChanges, true, operatorv1beta1.DNSChangeRequestPhasePending)},
+			podLogs:         podLogs,
+			expectedRequests: []testChangeRequestSummary{
+				{Phase: operatorv1beta1.DNSChangeRequestPhasePending, Approved: true, Changes: driftedChanges},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().
+				WithScheme(test.Scheme).
+				WithRuntimeObjects(tc.existingObjects...).
+				WithStatusSubresource(&operatorv1beta1.DNSChangeRequest{}).
+				Build()
+			r := &reconciler{
+				config: Config{
+					Namespace:         test.OperandNamespace,
+					OperatorNamespace: test.OperatorNamespace,
+				},
+				client:   cl,
+				scheme:   test.Scheme,
+				log:      zap.New(zap.UseDevMode(true)),
+				podLogs:  tc.podLogs,
+				recorder: record.NewFakeRecorder(10),
+			}
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			extDNS.Spec.ChangeApproval = tc.changeApproval
+
+			if err := r.ensureExternalDNSChangeRequests(context.TODO(), extDNS, []*appsv1.Deployment{deployment}); err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			requests := &operatorv1beta1.DNSChangeRequestList{}
+			if err := cl.List(context.TODO(), requests); err != nil {
+				t.Fatalf("failed to list DNS change requests: %v", err)
+			}
+			gotRequests := []testChangeRequestSummary{}
+			for _, req := range requests.Items {
+				gotRequests = append(gotRequests, testChangeRequestSummary{
+					Phase:    req.Status.Phase,
+					Approved: req.Spec.Approved,
+					Changes:  req.Spec.Changes,
+				})
+			}
+			sort.Slice(gotRequests, func(i, j int) bool { return gotRequests[i].Phase < gotRequests[j].Phase })
+			if tc.expectedRequests == nil {
+				tc.expectedRequests = []testChangeRequestSummary{}
+			}
+			if diff := cmp.Diff(tc.expectedRequests, gotRequests); diff != "" {
+				t.Errorf("unexpected DNS change requests (-want +got):\n%s", diff)
+			}
+
+			for role, expectedArgs := range tc.expectedJobsArgs {
+				job := &batchv1.Job{}
+				if err := cl.Get(context.TODO(), types.NamespacedName{Namespace: test.OperandNamespace, Name: testChangeRequestName + "-" + role}, job); err != nil {
+					t.Fatalf("failed to get the %s job: %v", role, err)
+				}
+				if len(job.Spec.Template.Spec.Containers) != 1 {
+					t.Fatalf("expected the %s job to run only the ExternalDNS container, got %v", role, job.Spec.Template.Spec.Containers)
+				}
+				if diff := cmp.Diff(expectedArgs, job.Spec.Template.Spec.Containers[0].Args); diff != "" {
+					t.Errorf("unexpected %s job args (-want +got):\n%s", role, diff)
+				}
+				if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+					t.Errorf("expected %s job restart policy %q, got %q", role, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+				}
+				if _, found := job.Spec.Template.Labels[appInstanceLabel]; found {
+					t.Errorf("expected %s job pods not to be labeled as operand pods, got labels %v", role, job.Spec.Template.Labels)
+				}
+			}
+		})
+	}
+}
+
+func TestDNSChangesDomainRegexp(t *testing.T) {
+	changes := operatorv1beta1.DNSChanges{
+		Creates: []string{"app.test.com A", "external-dns-a-app.test.com TXT"},
+		Deletes: []string{"*.old.test.com CNAME"},
+	}
+	re := regexp.MustCompile(dnsChangesDomainRegexp(changes))
+
+	for _, domain := range []string{"app.test.com", "external-dns-a-app.test.com", "*.old.test.com", "old.test.com", "test.com"} {
+		if !re.MatchString(domain) {
+			t.Errorf("expected %q to be matched by %q", domain, re)
+		}
+	}
+	for _, domain := range []string{"sub.app.test.com", "api.test.com", "x.old.test.com", "app.test.com.evil.com"} {
+		if re.MatchString(domain) {
+			t.Errorf("expected %q not to be matched by %q", domain, re)
+		}
+	}
+}
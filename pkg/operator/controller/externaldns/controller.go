@@ -23,10 +23,13 @@ import (
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -50,6 +53,8 @@ type Config struct {
 	Namespace string
 	// Image is the ExternalDNS image to use.
 	Image string
+	// ProxyImage is the kube-rbac-proxy image of the sidecars serving the endpoints of ExternalDNS containers.
+	ProxyImage string
 	// OperatorNamespace is the namespace in which this operator is deployed.
 	OperatorNamespace string
 	// IsOpenShift is the flag which instructs the operator that it runs in OpenShift.
@@ -64,10 +69,12 @@ type Config struct {
 
 // reconciler reconciles an ExternalDNS object.
 type reconciler struct {
-	config Config
-	client client.Client
-	scheme *runtime.Scheme
-	log    logr.Logger
+	config   Config
+	client   client.Client
+	scheme   *runtime.Scheme
+	log      logr.Logger
+	podLogs  podLogsReader
+	recorder record.EventRecorder
 }
 
 // New creates the externaldns controller from mgr and cfg. The controller will be pre-configured
@@ -78,11 +85,18 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 	operatorScheme := mgr.GetScheme()
 	operatorRESTMapper := mgr.GetRESTMapper()
 
+	kubeClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+
 	r := &reconciler{
-		config: cfg,
-		client: mgr.GetClient(),
-		scheme: mgr.GetScheme(),
-		log:    log,
+		config:   cfg,
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		log:      log,
+		podLogs:  &clientsetPodLogsReader{pods: kubeClient.CoreV1()},
+		recorder: mgr.GetEventRecorderFor(controlleroperator.ControllerName),
 	}
 
 	c, err := controller.New(controlleroperator.ControllerName, mgr, controller.Options{Reconciler: r})
@@ -102,6 +116,10 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 		return nil, err
 	}
 
+	if err := c.Watch(source.Kind[client.Object](operatorCache, &corev1.Service{}, handler.EnqueueRequestForOwner(operatorScheme, operatorRESTMapper, &operatorv1beta1.ExternalDNS{}, handler.OnlyControllerOwner()))); err != nil {
+		return nil, err
+	}
+
 	// secret replicated by the credentials controller
 	// needs to trigger the reconciliation of the corresponding ExternalDNS
 	// because of the annotation with the secret's hash in the operand deployment
@@ -109,6 +127,16 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 		return nil, err
 	}
 
+	// approval of the change requests and completion of their apply jobs
+	// need to trigger the reconciliation of the corresponding ExternalDNS
+	if err := c.Watch(source.Kind[client.Object](operatorCache, &operatorv1beta1.DNSChangeRequest{}, handler.EnqueueRequestForOwner(operatorScheme, operatorRESTMapper, &operatorv1beta1.ExternalDNS{}, handler.OnlyControllerOwner()))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Watch(source.Kind[client.Object](operatorCache, &batchv1.Job{}, handler.EnqueueRequestForOwner(operatorScheme, operatorRESTMapper, &operatorv1beta1.ExternalDNS{}, handler.OnlyControllerOwner()))); err != nil {
+		return nil, err
+	}
+
 	// enqueue all ExternalDNS instances if the trusted CA config map changed
 	// EnqueueRequestForOwner won't work here
 	// because the trusted CA configmap doesn't belong to any particular ExternalDNS instance
@@ -140,6 +168,76 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 		return nil, err
 	}
 
+	// Infoblox grid CA configmap copied into the operand namespace
+	// needs to trigger the reconciliation of the corresponding ExternalDNS
+	// because of the annotation with the configmap's hash in the operand deployment
+	if err := c.Watch(source.Kind[client.Object](operatorCache, &corev1.ConfigMap{}, handler.EnqueueRequestForOwner(operatorScheme, operatorRESTMapper, &operatorv1beta1.ExternalDNS{}, handler.OnlyControllerOwner()))); err != nil {
+		return nil, err
+	}
+
+	// enqueue ExternalDNS instances which reference
+	// the changed Infoblox grid CA configmap from the operator namespace
+	extDNSInstancesForGridCA := func(ctx context.Context, o client.Object) []reconcile.Request {
+		externalDNSList := &operatorv1beta1.ExternalDNSList{}
+		requests := []reconcile.Request{}
+		if err := mgr.GetCache().List(ctx, externalDNSList); err != nil {
+			log.Error(err, "failed to list externalDNS for Infoblox grid CA configmap")
+			return requests
+		}
+		for i := range externalDNSList.Items {
+			ed := &externalDNSList.Items[i]
+			if controlleroperator.ExternalDNSInfobloxCAConfigMapNameFromProvider(ed) != o.GetName() {
+				continue
+			}
+			log.Info("queueing externalDNS for Infoblox grid CA configmap", "name", ed.Name)
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: ed.Name,
+				},
+			})
+		}
+		return requests
+	}
+	if err := c.Watch(
+		source.Kind[client.Object](operatorCache, &corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(extDNSInstancesForGridCA),
+			predicate.NewPredicateFuncs(ctrlutils.InNamespace(cfg.OperatorNamespace)),
+		)); err != nil {
+		return nil, err
+	}
+
+	// enqueue ExternalDNS instances which reference
+	// the changed TXT encryption secret from the operator namespace,
+	// the copy in the operand namespace is watched as any other owned secret
+	extDNSInstancesForTXTEncryption := func(ctx context.Context, o client.Object) []reconcile.Request {
+		externalDNSList := &operatorv1beta1.ExternalDNSList{}
+		requests := []reconcile.Request{}
+		if err := mgr.GetCache().List(ctx, externalDNSList); err != nil {
+			log.Error(err, "failed to list externalDNS for TXT encryption secret")
+			return requests
+		}
+		for i := range externalDNSList.Items {
+			ed := &externalDNSList.Items[i]
+			if controlleroperator.ExternalDNSTXTEncryptionSecretNameFromRegistry(ed) != o.GetName() {
+				continue
+			}
+			log.Info("queueing externalDNS for TXT encryption secret", "name", ed.Name)
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: ed.Name,
+				},
+			})
+		}
+		return requests
+	}
+	if err := c.Watch(
+		source.Kind[client.Object](operatorCache, &corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(extDNSInstancesForTXTEncryption),
+			predicate.NewPredicateFuncs(ctrlutils.InNamespace(cfg.OperatorNamespace)),
+		)); err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
 
@@ -158,11 +256,30 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return reconcile.Result{}, fmt.Errorf("failed to get externalDNS %s: %w", req, err)
 	}
 
+	if !externalDNS.DeletionTimestamp.IsZero() {
+		removed, err := r.finalizeExternalDNS(ctx, externalDNS)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to finalize externalDNS: %w", err)
+		}
+		if !removed {
+			// the record cleanup is in progress
+			return reconcile.Result{RequeueAfter: recordCleanupCheckPeriod}, nil
+		}
+		reqLogger.Info("externalDNS is being deleted; reconciliation will be skipped")
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.ensureExternalDNSFinalizer(ctx, externalDNS); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS finalizer: %w", err)
+	}
+
 	// request credentials from CCO only if all of the following is true:
+	//  - instance is not paused
 	//  - underlying platform is OpenShift
 	//  - DNS provider is supported by CCO
 	//  - no credentials secret was provided
-	if r.config.IsOpenShift &&
+	if !externalDNS.Spec.Paused &&
+		r.config.IsOpenShift &&
 		operatorutils.ManagedCredentialsProvider(externalDNS) &&
 		controlleroperator.ExternalDNSCredentialsSecretNameFromProvider(externalDNS) == "" {
 		if _, _, err := r.ensureExternalCredentialsRequest(ctx, externalDNS); err != nil {
@@ -170,6 +287,10 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	if err := r.ensureOperandNamespacePodSecurity(ctx); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure pod security of operand namespace: %w", err)
+	}
+
 	haveServiceAccount, sa, err := r.ensureExternalDNSServiceAccount(ctx, r.config.Namespace, externalDNS)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS service account: %w", err)
@@ -177,19 +298,43 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return reconcile.Result{}, fmt.Errorf("failed to get externalDNS service account: %w", err)
 	}
 
-	credSecretNsName := controlleroperator.ExternalDNSDestCredentialsSecretName(r.config.Namespace, externalDNS.Name)
-	credSecretExists, credSecret, err := r.currentExternalDNSSecret(ctx, credSecretNsName)
-	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed to get the target credentials secret: %w", err)
+	var credSecret *corev1.Secret
+	if operatorutils.CredentialsRequiredProvider(externalDNS) {
+		credSecretNsName := controlleroperator.ExternalDNSDestCredentialsSecretName(r.config.Namespace, externalDNS.Name)
+		credSecretExists, secret, err := r.currentExternalDNSSecret(ctx, credSecretNsName)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to get the target credentials secret: %w", err)
+		}
+		if !credSecretExists {
+			// show that the secret is not there yet
+			if err := r.updateExternalDNSStatus(ctx, externalDNS, nil, false, externalDNS.Status.DeletionGuard); err != nil {
+				reqLogger.Error(err, "failed to update externalDNS custom resource")
+			}
+			// credentials secret was not synced yet or doesn't exist at all,
+			// either way: no need to requeue immediately polluting the logs.
+			return reconcile.Result{RequeueAfter: r.config.RequeuePeriod}, fmt.Errorf("target credentials secret %s not found", credSecretNsName)
+		}
+		credSecret = secret
 	}
-	if !credSecretExists {
-		// show that the secret is not there yet
-		if err := r.updateExternalDNSStatus(ctx, externalDNS, nil, false); err != nil {
-			reqLogger.Error(err, "failed to update externalDNS custom resource")
+
+	additionalCredSecrets := map[operatorv1beta1.ExternalDNSProviderType]*corev1.Secret{}
+	for _, target := range externalDNS.Spec.AdditionalProviders {
+		if !operatorutils.CredentialsRequiredProvider(operatorutils.ExternalDNSForProviderTarget(externalDNS, target)) {
+			continue
+		}
+		credSecretNsName := controlleroperator.ExternalDNSDestProviderCredentialsSecretName(r.config.Namespace, externalDNS.Name, target.Type)
+		credSecretExists, secret, err := r.currentExternalDNSSecret(ctx, credSecretNsName)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to get the target credentials secret of %q provider: %w", target.Type, err)
+		}
+		if !credSecretExists {
+			// show which provider misses the secret
+			if err := r.updateExternalDNSStatus(ctx, externalDNS, nil, true, externalDNS.Status.DeletionGuard); err != nil {
+				reqLogger.Error(err, "failed to update externalDNS custom resource")
+			}
+			return reconcile.Result{RequeueAfter: r.config.RequeuePeriod}, fmt.Errorf("target credentials secret %s of %q provider not found", credSecretNsName, target.Type)
 		}
-		// credentials secret was not synced yet or doesn't exist at all,
-		// either way: no need to requeue immediately polluting the logs.
-		return reconcile.Result{RequeueAfter: r.config.RequeuePeriod}, fmt.Errorf("target credentials secret %s not found", credSecretNsName)
+		additionalCredSecrets[target.Type] = secret
 	}
 
 	var trustCAConfigMap *corev1.ConfigMap
@@ -207,14 +352,90 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		trustCAConfigMap = configMap
 	}
 
-	_, currentDeployment, err := r.ensureExternalDNSDeployment(ctx, r.config.Namespace, r.config.Image, sa, credSecret, trustCAConfigMap, externalDNS)
+	var infobloxGridCAConfigMap *corev1.ConfigMap
+	if controlleroperator.ExternalDNSInfobloxCAConfigMapNameFromProvider(externalDNS) != "" {
+		sourceExists, configMap, err := r.ensureExternalDNSInfobloxGridCAConfigMap(ctx, externalDNS)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to ensure the Infoblox grid CA configmap: %w", err)
+		}
+		if !sourceExists {
+			// the config map may be created later by the user,
+			// no need to requeue immediately polluting the logs.
+			return reconcile.Result{RequeueAfter: r.config.RequeuePeriod}, fmt.Errorf("source Infoblox grid CA configmap %s/%s not found", r.config.OperatorNamespace, controlleroperator.ExternalDNSInfobloxCAConfigMapNameFromProvider(externalDNS))
+		}
+		infobloxGridCAConfigMap = configMap
+	} else if err := r.deleteExternalDNSInfobloxGridCAConfigMap(ctx, externalDNS); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to delete the Infoblox grid CA configmap: %w", err)
+	}
+
+	var txtEncryptionSecret *corev1.Secret
+	if controlleroperator.ExternalDNSTXTEncryptionSecretNameFromRegistry(externalDNS) != "" {
+		sourceExists, secret, err := r.ensureExternalDNSTXTEncryptionSecret(ctx, externalDNS)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to ensure the TXT encryption secret: %w", err)
+		}
+		if !sourceExists {
+			// the secret may be created later by the user,
+			// no need to requeue immediately polluting the logs.
+			return reconcile.Result{RequeueAfter: r.config.RequeuePeriod}, fmt.Errorf("source TXT encryption secret %s/%s not found", r.config.OperatorNamespace, controlleroperator.ExternalDNSTXTEncryptionSecretNameFromRegistry(externalDNS))
+		}
+		txtEncryptionSecret = secret
+	}
+
+	debugZoneExpiresIn, err := r.ensureExternalDNSDebugZone(ctx, externalDNS)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS debug zone: %w", err)
+	}
+
+	currentDeployments, err := r.ensureExternalDNSDeployments(ctx, r.config.Namespace, r.config.Image, sa, credSecret, additionalCredSecrets, trustCAConfigMap, infobloxGridCAConfigMap, txtEncryptionSecret, externalDNS)
 	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS deployment: %w", err)
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS deployments: %w", err)
 	}
 
-	if err := r.updateExternalDNSStatus(ctx, externalDNS, currentDeployment, true); err != nil {
+	// the dependencies of the record cleanup would be garbage collected before the cleanup
+	if err := r.ensureRecordCleanupDependencies(ctx, externalDNS, externalDNS.Spec.DeletionPolicy == operatorv1beta1.DeletionPolicyDelete); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS record cleanup dependencies: %w", err)
+	}
+
+	if err := r.ensureExternalDNSMetrics(ctx, externalDNS, currentDeployments); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS metrics: %w", err)
+	}
+
+	// the operand is scaled down: nothing is planned nor deleted
+	if externalDNS.Spec.Paused {
+		if err := r.updateExternalDNSStatus(ctx, externalDNS, currentDeployments, true, externalDNS.Status.DeletionGuard); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update externalDNS custom resource %s: %w", externalDNS.Name, err)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.ensureExternalDNSDryRunConfigMap(ctx, externalDNS, currentDeployments); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS dry run configmap: %w", err)
+	}
+
+	if err := r.ensureExternalDNSChangeRequests(ctx, externalDNS, currentDeployments); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS change requests: %w", err)
+	}
+
+	deletionGuard, err := r.ensureExternalDNSDeletionGuard(ctx, externalDNS, currentDeployments)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS deletion guard: %w", err)
+	}
+
+	if err := r.updateExternalDNSStatus(ctx, externalDNS, currentDeployments, true, deletionGuard); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to update externalDNS custom resource %s: %w", externalDNS.Name, err)
 	}
 
-	return reconcile.Result{}, nil
+	// nothing triggers the reconciliation when the migration period is over
+	if ownerIDMigrationSource(externalDNS) != "" {
+		return requeueBeforeDebugZoneExpiry(reconcile.Result{RequeueAfter: ownerIDMigrationCheckPeriod}, debugZoneExpiresIn), nil
+	}
+
+	// nothing triggers the reconciliation when the operand logs new planned changes
+	if externalDNS.Spec.DryRun || externalDNS.Spec.ChangeApproval == operatorv1beta1.ChangeApprovalManual || externalDNS.Spec.DeletionGuard != nil {
+		return requeueBeforeDebugZoneExpiry(reconcile.Result{RequeueAfter: dryRunCheckPeriod}, debugZoneExpiresIn), nil
+	}
+
+	// nothing triggers the reconciliation when the debug zone expires
+	return requeueBeforeDebugZoneExpiry(reconcile.Result{}, debugZoneExpiresIn), nil
 }
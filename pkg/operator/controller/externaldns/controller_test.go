@@ -49,6 +49,7 @@ const (
 	externalDNSResource        = "externaldns"
 	serviceAccountResource     = "serviceaccount"
 	credentialsrequestResource = "credentialsrequest"
+	namespaceResource          = "namespace"
 )
 
 func TestReconcile(t *testing.T) {
@@ -72,7 +73,7 @@ func TestReconcile(t *testing.T) {
 	}{
 		{
 			name:            "Bootstrap",
-			existingObjects: []runtime.Object{testExtDNSInstance(), testSecret()},
+			existingObjects: []runtime.Object{testExtDNSInstance(), testSecret(), testOperandNamespace(nil)},
 			inputConfig:     testConfig(),
 			inputRequest:    testRequest(),
 			expectedResult:  reconcile.Result{},
@@ -93,6 +94,13 @@ func TestReconcile(t *testing.T) {
 						Name:      test.OperandName,
 					},
 				},
+				{
+					EventType: watch.Modified,
+					ObjType:   namespaceResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.OperandNamespace,
+					},
+				},
 				{
 					EventType: watch.Modified,
 					ObjType:   externalDNSResource,
@@ -104,7 +112,7 @@ func TestReconcile(t *testing.T) {
 		},
 		{
 			name:            "Bootstrap when OCP",
-			existingObjects: []runtime.Object{testExtDNSInstanceNoSecret(), testSecret()},
+			existingObjects: []runtime.Object{testExtDNSInstanceNoSecret(), testSecret(), testOperandNamespace(nil)},
 			inputConfig:     testConfigOpenShift(),
 			inputRequest:    testRequest(),
 			expectedResult:  reconcile.Result{},
@@ -132,6 +140,13 @@ func TestReconcile(t *testing.T) {
 						Name:      test.OperandName,
 					},
 				},
+				{
+					EventType: watch.Modified,
+					ObjType:   namespaceResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.OperandNamespace,
+					},
+				},
 				{
 					EventType: watch.Modified,
 					ObjType:   externalDNSResource,
@@ -143,10 +158,120 @@ func TestReconcile(t *testing.T) {
 		},
 		{
 			name:            "Bootstrap when OCP and secret is given",
-			existingObjects: []runtime.Object{testExtDNSInstance(), testSecret()},
+			existingObjects: []runtime.Object{testExtDNSInstance(), testSecret(), testOperandNamespace(nil)},
 			inputConfig:     testConfigOpenShift(),
 			inputRequest:    testRequest(),
 			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   deploymentResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Added,
+					ObjType:   serviceAccountResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   namespaceResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.OperandNamespace,
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
+		{
+			name:            "Bootstrap InMemory without secret",
+			existingObjects: []runtime.Object{testInMemoryExtDNSInstance(), testOperandNamespace(nil)},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   deploymentResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Added,
+					ObjType:   serviceAccountResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   namespaceResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.OperandNamespace,
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
+		{
+			name:            "Bootstrap with restricted operand namespace",
+			existingObjects: []runtime.Object{testExtDNSInstance(), testSecret(), testOperandNamespace(map[string]string{podSecurityEnforceLabel: podSecurityRestricted})},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   deploymentResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Added,
+					ObjType:   serviceAccountResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
+		{
+			name:            "Bootstrap with operand namespace enforcing another level",
+			existingObjects: []runtime.Object{testExtDNSInstance(), testSecret(), testOperandNamespace(map[string]string{podSecurityEnforceLabel: "baseline"})},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
 			expectedEvents: []test.Event{
 				{
 					EventType: watch.Added,
@@ -236,6 +361,15 @@ func testConfig() Config {
 	}
 }
 
+func testOperandNamespace(labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   test.OperandNamespace,
+			Labels: labels,
+		},
+	}
+}
+
 func testConfigOpenShift() Config {
 	return Config{
 		Namespace:   test.OperandNamespace,
@@ -290,6 +424,12 @@ func testExtDNSInstance() *operatorv1beta1.ExternalDNS {
 	return extDNS
 }
 
+func testInMemoryExtDNSInstance() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstanceNoSecret()
+	extDNS.Spec.Provider.Type = operatorv1beta1.ProviderTypeInMemory
+	return extDNS
+}
+
 func testSecret() *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 
 	configv1 "github.com/openshift/api/config/v1"
 	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
@@ -50,7 +51,13 @@ func (r *reconciler) ensureExternalCredentialsRequest(ctx context.Context, exter
 		Name:      controller.SecretFromCloudCredentialsOperator,
 		Namespace: r.config.OperatorNamespace,
 	}
-	desired, err := desiredCredentialsRequest(name, secretName, externalDNS, r.config.PlatformStatus)
+	// the credentials request is shared by all the instances of the same provider
+	instances, err := r.credentialsRequestInstances(ctx, externalDNS)
+	if err != nil {
+		return false, nil, err
+	}
+
+	desired, err := desiredCredentialsRequest(name, secretName, instances, r.config.PlatformStatus)
 	if err != nil {
 		return false, nil, err
 	}
@@ -71,6 +78,32 @@ func (r *reconciler) ensureExternalCredentialsRequest(ctx context.Context, exter
 	return true, current, nil
 }
 
+// credentialsRequestInstances returns the given externalDNS along with the other instances
+// which use the same credentials request: the instances of the same provider which rely on CCO for the credentials.
+// The deleted and paused instances don't contribute to the credentials request.
+func (r *reconciler) credentialsRequestInstances(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) ([]*operatorv1beta1.ExternalDNS, error) {
+	list := &operatorv1beta1.ExternalDNSList{}
+	if err := r.client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list externalDNS instances: %w", err)
+	}
+
+	name := controller.ExternalDNSCredentialsRequestName(externalDNS)
+	instances := []*operatorv1beta1.ExternalDNS{externalDNS}
+	for i := range list.Items {
+		ed := &list.Items[i]
+		if ed.Name == externalDNS.Name || !ed.DeletionTimestamp.IsZero() || ed.Spec.Paused {
+			continue
+		}
+		if controller.ExternalDNSCredentialsRequestName(ed) != name ||
+			!utils.ManagedCredentialsProvider(ed) ||
+			controller.ExternalDNSCredentialsSecretNameFromProvider(ed) != "" {
+			continue
+		}
+		instances = append(instances, ed)
+	}
+	return instances, nil
+}
+
 // currentExternalDNSCredentialsRequest returns true if credentials request exists.
 func (r *reconciler) currentExternalDNSCredentialsRequest(ctx context.Context, name types.NamespacedName) (bool, *cco.CredentialsRequest, error) {
 	cr := &cco.CredentialsRequest{}
@@ -110,8 +143,9 @@ func (r *reconciler) updateExternalDNSCredentialsRequest(ctx context.Context, cu
 	return true, nil
 }
 
-// desiredCredentialsRequestName returns the desired credentials request definition for externalDNS
-func desiredCredentialsRequest(name, secretName types.NamespacedName, externalDNS *operatorv1beta1.ExternalDNS, platformStatus *configv1.PlatformStatus) (*cco.CredentialsRequest, error) {
+// desiredCredentialsRequestName returns the desired credentials request definition for the given externalDNS instances.
+// The first instance defines the provider, the others only contribute the permissions specific to them.
+func desiredCredentialsRequest(name, secretName types.NamespacedName, instances []*operatorv1beta1.ExternalDNS, platformStatus *configv1.PlatformStatus) (*cco.CredentialsRequest, error) {
 	credentialsRequest := &cco.CredentialsRequest{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "CredentialsRequest",
@@ -135,7 +169,7 @@ func desiredCredentialsRequest(name, secretName types.NamespacedName, externalDN
 		return nil, err
 	}
 
-	providerSpec, err := createProviderConfig(externalDNS, platformStatus, codec)
+	providerSpec, err := createProviderConfig(instances, platformStatus, codec)
 
 	if err != nil {
 		return nil, err
@@ -222,37 +256,39 @@ func externalDNSCredentialsRequestChanged(current, desired, updated *cco.Credent
 	return changed, nil
 }
 
-func createProviderConfig(externalDNS *operatorv1beta1.ExternalDNS, platformStatus *configv1.PlatformStatus, codec *cco.ProviderCodec) (*runtime.RawExtension, error) {
-	switch externalDNS.Spec.Provider.Type {
+func createProviderConfig(instances []*operatorv1beta1.ExternalDNS, platformStatus *configv1.PlatformStatus, codec *cco.ProviderCodec) (*runtime.RawExtension, error) {
+	switch instances[0].Spec.Provider.Type {
 	case operatorv1beta1.ProviderTypeAWS:
 		region := ""
 		if platformStatus != nil && platformStatus.Type == configv1.AWSPlatformType && platformStatus.AWS != nil {
 			region = platformStatus.AWS.Region
 		}
+		statements := []cco.StatementEntry{
+			{
+				Effect: "Allow",
+				Action: []string{
+					"route53:ChangeResourceRecordSets",
+				},
+				Resource: arnPrefix(region) + ":route53:::hostedzone/*",
+			},
+			{
+				Effect: "Allow",
+				Action: []string{
+					"route53:ListHostedZones",
+					"route53:ListResourceRecordSets",
+					"tag:GetResources",
+					"sts:AssumeRole",
+				},
+				Resource: "*",
+			},
+		}
+		statements = append(statements, dynamoDBStatements(instances, region, platformStatus)...)
 		return codec.EncodeProviderSpec(
 			&cco.AWSProviderSpec{
 				TypeMeta: metav1.TypeMeta{
 					Kind: "AWSProviderSpec",
 				},
-				StatementEntries: []cco.StatementEntry{
-					{
-						Effect: "Allow",
-						Action: []string{
-							"route53:ChangeResourceRecordSets",
-						},
-						Resource: arnPrefix(region) + ":route53:::hostedzone/*",
-					},
-					{
-						Effect: "Allow",
-						Action: []string{
-							"route53:ListHostedZones",
-							"route53:ListResourceRecordSets",
-							"tag:GetResources",
-							"sts:AssumeRole",
-						},
-						Resource: "*",
-					},
-				},
+				StatementEntries: statements,
 			})
 	case operatorv1beta1.ProviderTypeGCP:
 		return codec.EncodeProviderSpec(
@@ -279,6 +315,46 @@ func createProviderConfig(externalDNS *operatorv1beta1.ExternalDNS, platformStat
 	return nil, nil
 }
 
+// dynamoDBStatements returns the statements granting the access to the DynamoDB tables
+// of the given instances which use the DynamoDB registry, one statement per table.
+func dynamoDBStatements(instances []*operatorv1beta1.ExternalDNS, region string, platformStatus *configv1.PlatformStatus) []cco.StatementEntry {
+	tables := map[string]struct{}{}
+	for _, externalDNS := range instances {
+		if registry := externalDNS.Spec.Registry; registry == nil || registry.Type != operatorv1beta1.RegistryTypeDynamoDB {
+			continue
+		}
+		tableRegion := dynamoDBRegion(externalDNS, platformStatus)
+		if tableRegion == "" {
+			tableRegion = "*"
+		}
+		tables[fmt.Sprintf("%s:dynamodb:%s:*:table/%s", arnPrefix(region), tableRegion, dynamoDBTable(externalDNS))] = struct{}{}
+	}
+
+	// sorted to keep the credentials request stable whatever the listing order is
+	resources := make([]string, 0, len(tables))
+	for resource := range tables {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	statements := []cco.StatementEntry{}
+	for _, resource := range resources {
+		// https://github.com/kubernetes-sigs/external-dns/blob/master/docs/registry/dynamodb.md
+		statements = append(statements, cco.StatementEntry{
+			Effect: "Allow",
+			Action: []string{
+				"dynamodb:DescribeTable",
+				"dynamodb:PartiQLDelete",
+				"dynamodb:PartiQLInsert",
+				"dynamodb:PartiQLUpdate",
+				"dynamodb:Scan",
+			},
+			Resource: resource,
+		})
+	}
+	return statements
+}
+
 func arnPrefix(region string) string {
 	if utils.IsUSGovAWSRegion(region) {
 		return "arn:aws-us-gov"
@@ -70,6 +70,46 @@ func TestEnsureCredentialsRequest(t *testing.T) {
 			},
 			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpecGovARN).build(),
 		},
+		{
+			name:            "Create credentials request from scratch in AWS with DynamoDB registry",
+			existingObjects: []runtime.Object{},
+			inputExtDNS: test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().WithZones("public-zone").WithRegistry(&operatorv1beta1.ExternalDNSRegistry{
+				Type:     operatorv1beta1.RegistryTypeDynamoDB,
+				DynamoDB: &operatorv1beta1.ExternalDNSDynamoDBRegistryOptions{Table: "ownership"},
+			}).Build(),
+			inputPlatformStatus: &configv1.PlatformStatus{
+				Type: configv1.AWSPlatformType,
+				AWS: &configv1.AWSPlatformStatus{
+					Region: "us-east-1",
+				},
+			},
+			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpecDynamoDB).build(),
+		},
+		{
+			name: "Create credentials request in AWS with DynamoDB registries of multiple instances",
+			existingObjects: []runtime.Object{
+				test.NewExternalDNS("other").WithAWS().WithRouteSource().WithZones("other-zone").WithRegistry(&operatorv1beta1.ExternalDNSRegistry{
+					Type:     operatorv1beta1.RegistryTypeDynamoDB,
+					DynamoDB: &operatorv1beta1.ExternalDNSDynamoDBRegistryOptions{Table: "other-ownership"},
+				}).Build(),
+				test.NewExternalDNS("paused").WithAWS().WithRouteSource().WithZones("paused-zone").WithPaused().WithRegistry(&operatorv1beta1.ExternalDNSRegistry{
+					Type:     operatorv1beta1.RegistryTypeDynamoDB,
+					DynamoDB: &operatorv1beta1.ExternalDNSDynamoDBRegistryOptions{Table: "paused-ownership"},
+				}).Build(),
+				test.NewExternalDNS("azure").WithAzure().WithRouteSource().WithZones("azure-zone").Build(),
+			},
+			inputExtDNS: test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().WithZones("public-zone").WithRegistry(&operatorv1beta1.ExternalDNSRegistry{
+				Type:     operatorv1beta1.RegistryTypeDynamoDB,
+				DynamoDB: &operatorv1beta1.ExternalDNSDynamoDBRegistryOptions{Table: "ownership"},
+			}).Build(),
+			inputPlatformStatus: &configv1.PlatformStatus{
+				Type: configv1.AWSPlatformType,
+				AWS: &configv1.AWSPlatformStatus{
+					Region: "us-east-1",
+				},
+			},
+			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpecMultipleDynamoDB).build(),
+		},
 		{
 			name:                      "Create credentials request from scratch in Azure",
 			existingObjects:           []runtime.Object{},
@@ -320,6 +360,32 @@ func desiredAWSProviderSpecGovARN() runtime.Object {
 	}
 }
 
+func desiredAWSProviderSpecDynamoDB() runtime.Object {
+	spec := desiredAWSProviderSpec().(*cco.AWSProviderSpec)
+	spec.StatementEntries = append(spec.StatementEntries, cco.StatementEntry{
+		Effect: "Allow",
+		Action: []string{
+			"dynamodb:DescribeTable",
+			"dynamodb:PartiQLDelete",
+			"dynamodb:PartiQLInsert",
+			"dynamodb:PartiQLUpdate",
+			"dynamodb:Scan",
+		},
+		Resource: "arn:aws:dynamodb:us-east-1:*:table/ownership",
+	})
+	return spec
+}
+
+func desiredAWSProviderSpecMultipleDynamoDB() runtime.Object {
+	// the table statements are sorted by the resource
+	spec := desiredAWSProviderSpecDynamoDB().(*cco.AWSProviderSpec)
+	last := len(spec.StatementEntries) - 1
+	other := spec.StatementEntries[last]
+	other.Resource = "arn:aws:dynamodb:us-east-1:*:table/other-ownership"
+	spec.StatementEntries = append(spec.StatementEntries[:last], other, spec.StatementEntries[last])
+	return spec
+}
+
 func undesiredAWSProviderSpec() runtime.Object {
 	return &cco.AWSProviderSpec{
 		TypeMeta: metav1.TypeMeta{
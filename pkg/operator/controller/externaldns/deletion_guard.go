@@ -0,0 +1,302 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+const (
+	deletionGuardBlockedEventReason      = "ChangesBlocked"
+	deletionGuardAcknowledgedEventReason = "BlockedChangesAcknowledged"
+
+	// deletionGuardJobLabel is the label of the deletion guard jobs and their pods with the role of the job.
+	deletionGuardJobLabel     = "externaldns.olm.openshift.io/deletion-guard"
+	deletionGuardPlanJobRole  = "plan"
+	deletionGuardApplyJobRole = "apply"
+	// deletionGuardPlanPeriod is the minimum period between the deletion plans.
+	deletionGuardPlanPeriod = 1 * time.Minute
+)
+
+// deletionGuardActive returns true if the deletions of the given externalDNS are guarded:
+// the operand deployments don't delete the records, the deletion guard jobs plan and apply the deletions.
+// Nothing is deleted by the operand in the dry run mode, the approved changes are applied by the change request jobs,
+// and nothing is deleted with the upsert-only and create-only policies.
+func deletionGuardActive(externalDNS *operatorv1beta1.ExternalDNS) bool {
+	if externalDNS.Spec.DeletionGuard == nil || externalDNS.Spec.DryRun || externalDNS.Spec.ChangeApproval == operatorv1beta1.ChangeApprovalManual {
+		return false
+	}
+	return externalDNS.Spec.Policy != operatorv1beta1.PolicyUpsertOnly && externalDNS.Spec.Policy != operatorv1beta1.PolicyCreateOnly
+}
+
+// ensureExternalDNSDeletionGuard ensures that the deletions of the operand containers are planned first
+// and that only the containers which planned no more than the maximum number of deletions apply them.
+// A guard cycle runs a one-shot dry run job of the operand containers, collects the deletions each container planned,
+// blocks the containers which exceeded the maximum and runs a one-shot apply job of the containers with the deletions within the limit.
+// The deletions of the blocked containers are applied by the next cycle once the acknowledgement annotation is set,
+// the annotation is removed from the given externalDNS afterwards.
+// Returns the desired status of the deletion guard, nil if the guard is not enabled, and an error when relevant.
+func (r *reconciler) ensureExternalDNSDeletionGuard(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, deployments []*appsv1.Deployment) (*operatorv1beta1.ExternalDNSDeletionGuardStatus, error) {
+	if !deletionGuardActive(externalDNS) {
+		if err := r.deleteDeletionGuardJobs(ctx, externalDNS); err != nil {
+			return nil, err
+		}
+		if externalDNS.Spec.DeletionGuard == nil {
+			return nil, nil
+		}
+		// nothing is blocked while the guard is inactive
+		status := &operatorv1beta1.ExternalDNSDeletionGuardStatus{}
+		if externalDNS.Status.DeletionGuard != nil {
+			status.AcknowledgedTime = externalDNS.Status.DeletionGuard.AcknowledgedTime
+		}
+		return status, nil
+	}
+
+	status := &operatorv1beta1.ExternalDNSDeletionGuardStatus{}
+	if externalDNS.Status.DeletionGuard != nil {
+		status = externalDNS.Status.DeletionGuard.DeepCopy()
+	}
+
+	planJob, err := r.currentDeletionGuardJob(ctx, externalDNS, deletionGuardPlanJobRole)
+	if err != nil {
+		return nil, err
+	}
+	applyJob, err := r.currentDeletionGuardJob(ctx, externalDNS, deletionGuardApplyJobRole)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cycle ends once the deletions are applied
+	if applyJob != nil {
+		finished, failed, message := jobResult(applyJob)
+		if !finished {
+			return status, nil
+		}
+		if failed {
+			r.log.Info("deletion guard apply job failed", "namespace", applyJob.Namespace, "name", applyJob.Name, "message", message)
+		}
+		return status, r.deleteDeletionGuardJobs(ctx, externalDNS)
+	}
+
+	if planJob == nil {
+		if len(deployments) == 0 || (status.PlannedTime != nil && clock.Since(status.PlannedTime.Time) < deletionGuardPlanPeriod) {
+			return status, nil
+		}
+		if err := r.createDeletionGuardJob(ctx, externalDNS, desiredDeletionGuardJob(externalDNS, deployments, deletionGuardPlanJobRole, nil, r.config.Namespace)); err != nil {
+			return nil, err
+		}
+		return status, nil
+	}
+
+	finished, failed, message := jobResult(planJob)
+	if !finished {
+		return status, nil
+	}
+	now := metav1.NewTime(clock.Now())
+	status.PlannedTime = &now
+	if failed {
+		r.log.Info("deletion guard plan job failed", "namespace", planJob.Namespace, "name", planJob.Name, "message", message)
+		return status, r.deleteDeletionGuardJobs(ctx, externalDNS)
+	}
+
+	plans := map[string]*dryRunPlan{}
+	if err := r.collectPodsContainerPlans(ctx, planJob.Namespace, planJob.Spec.Template.Labels, corev1.PodSucceeded, 0, plans); err != nil {
+		return nil, err
+	}
+
+	blocked, applied := []string{}, map[string]struct{}{}
+	for containerName, plan := range plans {
+		if !plan.recognized {
+			// the deletions of the container cannot be counted
+			applied[containerName] = struct{}{}
+			blocked = append(blocked, containerName)
+			r.recorder.Eventf(externalDNS, corev1.EventTypeWarning, dryRunUnrecognizedEventReason, "The planned changes of container %s could not be recognized in its logs, its deletions are blocked", containerName)
+			continue
+		}
+		if len(plan.deletes) == 0 {
+			continue
+		}
+		applied[containerName] = struct{}{}
+		if len(plan.deletes) > int(externalDNS.Spec.DeletionGuard.MaxDeletions) {
+			blocked = append(blocked, containerName)
+			r.log.Info("blocked the deletions of externalDNS container", "name", externalDNS.Name, "container", containerName, "deletions", len(plan.deletes), "maxDeletions", externalDNS.Spec.DeletionGuard.MaxDeletions)
+		}
+	}
+	sort.Strings(blocked)
+
+	if _, acknowledged := externalDNS.Annotations[operatorv1beta1.DeletionGuardAcknowledgeAnnotation]; acknowledged {
+		if len(blocked) > 0 {
+			r.recorder.Eventf(externalDNS, corev1.EventTypeNormal, deletionGuardAcknowledgedEventReason, "The deletions of containers %s were acknowledged", strings.Join(blocked, ", "))
+		}
+		blocked = nil
+		status.AcknowledgedTime = &now
+
+		patch := client.MergeFrom(externalDNS.DeepCopy())
+		delete(externalDNS.Annotations, operatorv1beta1.DeletionGuardAcknowledgeAnnotation)
+		if err := r.client.Patch(ctx, externalDNS, patch); err != nil {
+			return nil, fmt.Errorf("failed to remove the acknowledgement annotation from externalDNS %s: %w", externalDNS.Name, err)
+		}
+		r.log.Info("resumed the deletions of externalDNS", "name", externalDNS.Name)
+	}
+	status.BlockedContainers = blocked
+	for _, containerName := range blocked {
+		delete(applied, containerName)
+	}
+
+	if len(blocked) > 0 {
+		r.recorder.Eventf(externalDNS, corev1.EventTypeWarning, deletionGuardBlockedEventReason, "Containers %s planned more than the maximum of %d deletions which were not applied, annotate the ExternalDNS with %q to apply them", strings.Join(blocked, ", "), externalDNS.Spec.DeletionGuard.MaxDeletions, operatorv1beta1.DeletionGuardAcknowledgeAnnotation)
+	}
+
+	if len(applied) == 0 {
+		return status, r.deleteDeletionGuardJobs(ctx, externalDNS)
+	}
+	if err := r.createDeletionGuardJob(ctx, externalDNS, desiredDeletionGuardJob(externalDNS, deployments, deletionGuardApplyJobRole, applied, r.config.Namespace)); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// currentDeletionGuardJob returns the deletion guard job with the given role, nil if it doesn't exist.
+func (r *reconciler) currentDeletionGuardJob(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, role string) (*batchv1.Job, error) {
+	nsName := deletionGuardJobName(externalDNS, role, r.config.Namespace)
+	job := &batchv1.Job{}
+	if err := r.client.Get(ctx, nsName, job); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get deletion guard job %s: %w", nsName, err)
+	}
+	return job, nil
+}
+
+// createDeletionGuardJob creates the given deletion guard job controlled by the given externalDNS.
+func (r *reconciler) createDeletionGuardJob(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, job *batchv1.Job) error {
+	if err := controllerutil.SetControllerReference(externalDNS, job, r.scheme); err != nil {
+		return fmt.Errorf("failed to set the controller reference for deletion guard job: %w", err)
+	}
+	if err := r.client.Create(ctx, job); err != nil {
+		return fmt.Errorf("failed to create deletion guard job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	r.log.Info("created deletion guard job", "namespace", job.Namespace, "name", job.Name)
+	return nil
+}
+
+// deleteDeletionGuardJobs deletes the deletion guard jobs of the given externalDNS along with their pods.
+func (r *reconciler) deleteDeletionGuardJobs(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) error {
+	for _, role := range []string{deletionGuardPlanJobRole, deletionGuardApplyJobRole} {
+		job, err := r.currentDeletionGuardJob(ctx, externalDNS, role)
+		if err != nil {
+			return err
+		} else if job == nil {
+			continue
+		}
+		if err := r.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete deletion guard job %s/%s: %w", job.Namespace, job.Name, err)
+		}
+		r.log.Info("deleted deletion guard job", "namespace", job.Namespace, "name", job.Name)
+	}
+	return nil
+}
+
+// deletionGuardJobName returns the namespaced name of the deletion guard job with the given role.
+func deletionGuardJobName(externalDNS *operatorv1beta1.ExternalDNS, role, namespace string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: namespace,
+		Name:      controller.ExternalDNSResourceName(externalDNS) + "-deletion-" + role,
+	}
+}
+
+// desiredDeletionGuardJob returns the deletion guard job with the given role which runs the operand containers once with the sync policy.
+// The plan job runs all the containers in the dry run mode,
+// the apply job runs only the given containers.
+func desiredDeletionGuardJob(externalDNS *operatorv1beta1.ExternalDNS, deployments []*appsv1.Deployment, role string, containers map[string]struct{}, namespace string) *batchv1.Job {
+	labels := map[string]string{
+		changeRequestExternalDNSLabel: externalDNS.Name,
+		deletionGuardJobLabel:         role,
+	}
+	template := oneShotOperandTemplate(deployments, labels, func(arg string) (string, bool) {
+		if strings.HasPrefix(arg, policyArgPrefix) {
+			return policyArgPrefix + policySync, true
+		}
+		return arg, true
+	})
+	if role == deletionGuardPlanJobRole {
+		for i := range template.Spec.Containers {
+			template.Spec.Containers[i].Args = append(template.Spec.Containers[i].Args, dryRunArg)
+		}
+	} else {
+		applied := []corev1.Container{}
+		for _, container := range template.Spec.Containers {
+			if _, found := containers[container.Name]; found {
+				applied = append(applied, container)
+			}
+		}
+		template.Spec.Containers = applied
+	}
+
+	nsName := deletionGuardJobName(externalDNS, role, namespace)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsName.Name,
+			Namespace: nsName.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](0),
+			Template:     *template,
+		},
+	}
+}
+
+// computeDeletionGuardCondition returns the condition of the deletion guard based on the given guard status.
+// Returns nil if the deletion guard is not enabled.
+func computeDeletionGuardCondition(externalDNS *operatorv1beta1.ExternalDNS, status *operatorv1beta1.ExternalDNSDeletionGuardStatus) *metav1.Condition {
+	if externalDNS.Spec.DeletionGuard == nil {
+		return nil
+	}
+	if status != nil && len(status.BlockedContainers) > 0 {
+		return &metav1.Condition{
+			Type:    ExternalDNSChangesBlockedConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "TooManyDeletions",
+			Message: fmt.Sprintf("Containers %s exceeded the maximum of %d deletions, the deletions are blocked until acknowledged with the %q annotation.", strings.Join(status.BlockedContainers, ", "), externalDNS.Spec.DeletionGuard.MaxDeletions, operatorv1beta1.DeletionGuardAcknowledgeAnnotation),
+		}
+	}
+	return &metav1.Condition{
+		Type:    ExternalDNSChangesBlockedConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  "DeletionsWithinLimit",
+		Message: fmt.Sprintf("No container exceeded the maximum of %d deletions.", externalDNS.Spec.DeletionGuard.MaxDeletions),
+	}
+}
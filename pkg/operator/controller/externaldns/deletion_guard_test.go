@@ -0,0 +1,462 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestEnsureExternalDNSDeletionGuard(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      test.OperandName,
+			Namespace: test.OperandNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					appNameLabel:     ExternalDNSBaseName,
+					appInstanceLabel: test.Name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						appNameLabel:     ExternalDNSBaseName,
+						appInstanceLabel: test.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "external-dns-zone1",
+							Args: []string{"--provider=aws", "--policy=upsert-only"},
+						},
+						{
+							Name: "external-dns-zone2",
+							Args: []string{"--provider=aws", "--policy=upsert-only"},
+						},
+						testMetricsProxyContainer(7979),
+						testMetricsProxyContainer(7980),
+					},
+				},
+			},
+		},
+	}
+	extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+	job := func(role string, condType batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      deletionGuardJobName(extDNS, role, test.OperandNamespace).Name,
+				Namespace: test.OperandNamespace,
+			},
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							changeRequestExternalDNSLabel: test.Name,
+							deletionGuardJobLabel:         role,
+						},
+					},
+				},
+			},
+		}
+		if condType != "" {
+			job.Status.Conditions = []batchv1.JobCondition{
+				{
+					Type:   condType,
+					Status: corev1.ConditionTrue,
+				},
+			}
+		}
+		return job
+	}
+	planPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDryRunPodName,
+			Namespace: test.OperandNamespace,
+			Labels: map[string]string{
+				changeRequestExternalDNSLabel: test.Name,
+				deletionGuardJobLabel:         deletionGuardPlanJobRole,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "external-dns-zone1"},
+				{Name: "external-dns-zone2"},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+		},
+	}
+	podLogs := fakePodLogsReader{
+		testDryRunPodName + "/external-dns-zone1": `time="2024-05-14T10:00:01Z" level=info msg="Desired change: DELETE app.test.com A [Id: /hostedzone/zone1]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: DELETE external-dns-a-app.test.com TXT [Id: /hostedzone/zone1]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: DELETE api.test.com CNAME [Id: /hostedzone/zone1]"`,
+		testDryRunPodName + "/external-dns-zone2": `time="2024-05-14T10:00:01Z" level=info msg="Desired change: DELETE old.test.com A [Id: /hostedzone/zone2]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: CREATE new.test.com A [Id: /hostedzone/zone2]"`,
+	}
+	recentlyPlanned := metav1.NewTime(time.Now().Add(-deletionGuardPlanPeriod / 2))
+	longAgoPlanned := metav1.NewTime(time.Now().Add(-2 * deletionGuardPlanPeriod))
+
+	testCases := []struct {
+		name                    string
+		guard                   *operatorv1beta1.ExternalDNSDeletionGuard
+		dryRun                  bool
+		annotations             map[string]string
+		currentStatus           *operatorv1beta1.ExternalDNSDeletionGuardStatus
+		existingObjects         []runtime.Object
+		podLogs                 fakePodLogsReader
+		expectedStatus          *operatorv1beta1.ExternalDNSDeletionGuardStatus
+		expectedPlanned         bool
+		expectedAcknowledged    bool
+		expectedJobs            []string
+		expectedApplyContainers []string
+		expectedEventsReasons   []string
+		expectedAnnotationKept  bool
+	}{
+		{
+			name: "Guard is not enabled",
+		},
+		{
+			name:           "Deletions are planned first",
+			guard:          &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 3},
+			currentStatus:  &operatorv1beta1.ExternalDNSDeletionGuardStatus{PlannedTime: &longAgoPlanned},
+			expectedStatus: &operatorv1beta1.ExternalDNSDeletionGuardStatus{PlannedTime: &longAgoPlanned},
+			expectedJobs:   []string{deletionGuardPlanJobRole},
+		},
+		{
+			name:           "Deletions were planned recently",
+			guard:          &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 3},
+			currentStatus:  &operatorv1beta1.ExternalDNSDeletionGuardStatus{PlannedTime: &recentlyPlanned},
+			expectedStatus: &operatorv1beta1.ExternalDNSDeletionGuardStatus{PlannedTime: &recentlyPlanned},
+		},
+		{
+			name:            "Plan is running",
+			guard:           &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 3},
+			existingObjects: []runtime.Object{job(deletionGuardPlanJobRole, "")},
+			expectedStatus:  &operatorv1beta1.ExternalDNSDeletionGuardStatus{},
+			expectedJobs:    []string{deletionGuardPlanJobRole},
+		},
+		{
+			name:                    "Deletions are within the limit",
+			guard:                   &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 3},
+			existingObjects:         []runtime.Object{job(deletionGuardPlanJobRole, batchv1.JobComplete), planPod},
+			expectedStatus:          &operatorv1beta1.ExternalDNSDeletionGuardStatus{},
+			expectedPlanned:         true,
+			expectedJobs:            []string{deletionGuardApplyJobRole, deletionGuardPlanJobRole},
+			expectedApplyContainers: []string{"external-dns-zone1", "external-dns-zone2"},
+		},
+		{
+			name:            "Deletions exceed the limit",
+			guard:           &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 2},
+			existingObjects: []runtime.Object{job(deletionGuardPlanJobRole, batchv1.JobComplete), planPod},
+			expectedStatus: &operatorv1beta1.ExternalDNSDeletionGuardStatus{
+				BlockedContainers: []string{"external-dns-zone1"},
+			},
+			expectedPlanned:         true,
+			expectedJobs:            []string{deletionGuardApplyJobRole, deletionGuardPlanJobRole},
+			expectedApplyContainers: []string{"external-dns-zone2"},
+			expectedEventsReasons:   []string{deletionGuardBlockedEventReason},
+		},
+		{
+			name:            "Unrecognized plan is blocked",
+			guard:           &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 3},
+			existingObjects: []runtime.Object{job(deletionGuardPlanJobRole, batchv1.JobComplete), planPod},
+			podLogs: fakePodLogsReader{
+				testDryRunPodName + "/external-dns-zone1": podLogs[testDryRunPodName+"/external-dns-zone1"],
+				testDryRunPodName + "/external-dns-zone2": `time="2024-05-14T10:00:00Z" level=info msg="Instantiating new Kubernetes client"`,
+			},
+			expectedStatus: &operatorv1beta1.ExternalDNSDeletionGuardStatus{
+				BlockedContainers: []string{"external-dns-zone2"},
+			},
+			expectedPlanned:         true,
+			expectedJobs:            []string{deletionGuardApplyJobRole, deletionGuardPlanJobRole},
+			expectedApplyContainers: []string{"external-dns-zone1"},
+			expectedEventsReasons:   []string{dryRunUnrecognizedEventReason, deletionGuardBlockedEventReason},
+		},
+		{
+			name:  "Blocked containers are planned again",
+			guard: &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 2},
+			currentStatus: &operatorv1beta1.ExternalDNSDeletionGuardStatus{
+				BlockedContainers: []string{"external-dns-zone1"},
+				PlannedTime:       &longAgoPlanned,
+			},
+			expectedStatus: &operatorv1beta1.ExternalDNSDeletionGuardStatus{
+				BlockedContainers: []string{"external-dns-zone1"},
+				PlannedTime:       &longAgoPlanned,
+			},
+			expectedJobs: []string{deletionGuardPlanJobRole},
+		},
+		{
+			name:  "Deletions are acknowledged",
+			guard: &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 1},
+			annotations: map[string]string{
+				operatorv1beta1.DeletionGuardAcknowledgeAnnotation: "",
+			},
+			currentStatus: &operatorv1beta1.ExternalDNSDeletionGuardStatus{
+				BlockedContainers: []string{"external-dns-zone1"},
+			},
+			existingObjects:         []runtime.Object{job(deletionGuardPlanJobRole, batchv1.JobComplete), planPod},
+			expectedStatus:          &operatorv1beta1.ExternalDNSDeletionGuardStatus{},
+			expectedPlanned:         true,
+			expectedAcknowledged:    true,
+			expectedJobs:            []string{deletionGuardApplyJobRole, deletionGuardPlanJobRole},
+			expectedApplyContainers: []string{"external-dns-zone1", "external-dns-zone2"},
+			expectedEventsReasons:   []string{deletionGuardAcknowledgedEventReason},
+		},
+		{
+			name:  "Acknowledgement waits for the plan",
+			guard: &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 1},
+			annotations: map[string]string{
+				operatorv1beta1.DeletionGuardAcknowledgeAnnotation: "",
+			},
+			existingObjects:        []runtime.Object{job(deletionGuardPlanJobRole, "")},
+			expectedStatus:         &operatorv1beta1.ExternalDNSDeletionGuardStatus{},
+			expectedJobs:           []string{deletionGuardPlanJobRole},
+			expectedAnnotationKept: true,
+		},
+		{
+			name:            "Plan failed",
+			guard:           &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 3},
+			existingObjects: []runtime.Object{job(deletionGuardPlanJobRole, batchv1.JobFailed)},
+			expectedStatus:  &operatorv1beta1.ExternalDNSDeletionGuardStatus{},
+			expectedPlanned: true,
+		},
+		{
+			name:            "Deletions are being applied",
+			guard:           &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 3},
+			existingObjects: []runtime.Object{job(deletionGuardPlanJobRole, batchv1.JobComplete), job(deletionGuardApplyJobRole, "")},
+			expectedStatus:  &operatorv1beta1.ExternalDNSDeletionGuardStatus{},
+			expectedJobs:    []string{deletionGuardApplyJobRole, deletionGuardPlanJobRole},
+		},
+		{
+			name:            "Deletions were applied",
+			guard:           &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 3},
+			existingObjects: []runtime.Object{job(deletionGuardPlanJobRole, batchv1.JobComplete), job(deletionGuardApplyJobRole, batchv1.JobComplete)},
+			expectedStatus:  &operatorv1beta1.ExternalDNSDeletionGuardStatus{},
+		},
+		{
+			name:   "Dry run doesn't delete",
+			guard:  &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 1},
+			dryRun: true,
+			currentStatus: &operatorv1beta1.ExternalDNSDeletionGuardStatus{
+				BlockedContainers: []string{"external-dns-zone1"},
+			},
+			existingObjects: []runtime.Object{job(deletionGuardPlanJobRole, "")},
+			expectedStatus:  &operatorv1beta1.ExternalDNSDeletionGuardStatus{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			extDNS.Annotations = tc.annotations
+			extDNS.Spec.DeletionGuard = tc.guard
+			extDNS.Spec.DryRun = tc.dryRun
+			extDNS.Status.DeletionGuard = tc.currentStatus
+
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(append(tc.existingObjects, extDNS)...).Build()
+			recorder := record.NewFakeRecorder(10)
+			if tc.podLogs == nil {
+				tc.podLogs = podLogs
+			}
+			r := &reconciler{
+				config: Config{
+					Namespace:         test.OperandNamespace,
+					OperatorNamespace: test.OperatorNamespace,
+				},
+				client:   cl,
+				scheme:   test.Scheme,
+				log:      zap.New(zap.UseDevMode(true)),
+				podLogs:  tc.podLogs,
+				recorder: recorder,
+			}
+
+			gotStatus, err := r.ensureExternalDNSDeletionGuard(context.TODO(), extDNS, []*appsv1.Deployment{deployment})
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			diffOpts := cmpopts.IgnoreFields(operatorv1beta1.ExternalDNSDeletionGuardStatus{}, "AcknowledgedTime", "PlannedTime")
+			if diff := cmp.Diff(tc.expectedStatus, gotStatus, cmpopts.EquateEmpty(), diffOpts); diff != "" {
+				t.Errorf("unexpected deletion guard status (-want +got):\n%s", diff)
+			}
+			if tc.expectedStatus != nil && gotStatus != nil {
+				if tc.expectedAcknowledged && gotStatus.AcknowledgedTime == nil {
+					t.Error("expected the acknowledgement time to be set")
+				} else if !tc.expectedAcknowledged && !cmp.Equal(tc.expectedStatus.AcknowledgedTime, gotStatus.AcknowledgedTime) {
+					t.Errorf("expected the acknowledgement time %v, got %v", tc.expectedStatus.AcknowledgedTime, gotStatus.AcknowledgedTime)
+				}
+				if tc.expectedPlanned && (gotStatus.PlannedTime == nil || clock.Since(gotStatus.PlannedTime.Time) > time.Minute) {
+					t.Errorf("expected the planned time to be updated, got %v", gotStatus.PlannedTime)
+				} else if !tc.expectedPlanned && !cmp.Equal(tc.expectedStatus.PlannedTime, gotStatus.PlannedTime) {
+					t.Errorf("expected the planned time %v, got %v", tc.expectedStatus.PlannedTime, gotStatus.PlannedTime)
+				}
+			}
+
+			gotExtDNS := &operatorv1beta1.ExternalDNS{}
+			if err := cl.Get(context.TODO(), types.NamespacedName{Name: test.Name}, gotExtDNS); err != nil {
+				t.Fatalf("failed to get externalDNS: %v", err)
+			}
+			if _, found := gotExtDNS.Annotations[operatorv1beta1.DeletionGuardAcknowledgeAnnotation]; found != tc.expectedAnnotationKept {
+				t.Errorf("expected the acknowledgement annotation to be kept: %t, got: %t", tc.expectedAnnotationKept, found)
+			}
+
+			jobs := &batchv1.JobList{}
+			if err := cl.List(context.TODO(), jobs); err != nil {
+				t.Fatalf("failed to list jobs: %v", err)
+			}
+			gotJobs := []string{}
+			for _, job := range jobs.Items {
+				role := job.Spec.Template.Labels[deletionGuardJobLabel]
+				gotJobs = append(gotJobs, role)
+				if job.Name != deletionGuardJobName(extDNS, role, test.OperandNamespace).Name {
+					t.Errorf("unexpected name %q of the %s job", job.Name, role)
+				}
+				if role != deletionGuardApplyJobRole || tc.expectedApplyContainers == nil {
+					continue
+				}
+				gotContainers := []string{}
+				for _, container := range job.Spec.Template.Spec.Containers {
+					gotContainers = append(gotContainers, container.Name)
+					if diff := cmp.Diff([]string{"--provider=aws", "--policy=sync", "--once"}, container.Args); diff != "" {
+						t.Errorf("unexpected args of apply job container %q (-want +got):\n%s", container.Name, diff)
+					}
+				}
+				if diff := cmp.Diff(tc.expectedApplyContainers, gotContainers); diff != "" {
+					t.Errorf("unexpected apply job containers (-want +got):\n%s", diff)
+				}
+			}
+			sort.Strings(gotJobs)
+			if diff := cmp.Diff(tc.expectedJobs, gotJobs, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected deletion guard jobs (-want +got):\n%s", diff)
+			}
+
+			close(recorder.Events)
+			gotReasons := []string{}
+			for event := range recorder.Events {
+				// the fake recorder formats the events as "<type> <reason> <message>"
+				gotReasons = append(gotReasons, strings.Fields(event)[1])
+			}
+			if diff := cmp.Diff(tc.expectedEventsReasons, gotReasons, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected events (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDesiredDeletionGuardPlanJob(t *testing.T) {
+	extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+	extDNS.Spec.DeletionGuard = &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 10}
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "external-dns-zone1",
+							Args: []string{"--provider=aws", "--policy=upsert-only"},
+						},
+						testMetricsProxyContainer(7979),
+					},
+				},
+			},
+		},
+	}
+
+	job := desiredDeletionGuardJob(extDNS, []*appsv1.Deployment{deployment}, deletionGuardPlanJobRole, nil, test.OperandNamespace)
+	if len(job.Spec.Template.Spec.Containers) != 1 {
+		t.Fatalf("expected the plan job to run only the ExternalDNS container, got %v", job.Spec.Template.Spec.Containers)
+	}
+	if diff := cmp.Diff([]string{"--provider=aws", "--policy=sync", "--once", "--dry-run"}, job.Spec.Template.Spec.Containers[0].Args); diff != "" {
+		t.Errorf("unexpected plan job args (-want +got):\n%s", diff)
+	}
+	if _, found := job.Spec.Template.Labels[appInstanceLabel]; found {
+		t.Errorf("expected plan job pods not to be labeled as operand pods, got labels %v", job.Spec.Template.Labels)
+	}
+}
+
+func TestComputeDeletionGuardCondition(t *testing.T) {
+	testCases := []struct {
+		name              string
+		guard             *operatorv1beta1.ExternalDNSDeletionGuard
+		status            *operatorv1beta1.ExternalDNSDeletionGuardStatus
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "Guard is not enabled",
+		},
+		{
+			name:   "No container is blocked",
+			guard:  &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 10},
+			status: &operatorv1beta1.ExternalDNSDeletionGuardStatus{},
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSChangesBlockedConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "DeletionsWithinLimit",
+				Message: "No container exceeded the maximum of 10 deletions.",
+			},
+		},
+		{
+			name:  "Container is blocked",
+			guard: &operatorv1beta1.ExternalDNSDeletionGuard{MaxDeletions: 10},
+			status: &operatorv1beta1.ExternalDNSDeletionGuardStatus{
+				BlockedContainers: []string{"external-dns-zone1"},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSChangesBlockedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "TooManyDeletions",
+				Message: `Containers external-dns-zone1 exceeded the maximum of 10 deletions, the deletions are blocked until acknowledged with the "externaldns.olm.openshift.io/acknowledge-blocked-changes" annotation.`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			extDNS.Spec.DeletionGuard = tc.guard
+
+			got := computeDeletionGuardCondition(extDNS, tc.status)
+			if diff := cmp.Diff(tc.expectedCondition, got); diff != "" {
+				t.Errorf("unexpected condition (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
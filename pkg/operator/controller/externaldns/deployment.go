@@ -23,21 +23,26 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	configv1 "github.com/openshift/api/config/v1"
 
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
 	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	operatorutils "github.com/openshift/external-dns-operator/pkg/utils"
 )
 
 const (
@@ -47,6 +52,7 @@ const (
 	externalDNSProviderTypeAzurePrivate = "azure-private-dns"
 	externalDNSProviderTypeBlueCat      = "bluecat"
 	externalDNSProviderTypeInfoblox     = "infoblox"
+	externalDNSProviderTypeInMemory     = "inmemory"
 	appNameLabel                        = "app.kubernetes.io/name"
 	appInstanceLabel                    = "app.kubernetes.io/instance"
 	masterNodeRoleLabel                 = "node-role.kubernetes.io/master"
@@ -55,6 +61,10 @@ const (
 	azurePrivateDNSZonesResourceSubStr  = "privatednszones"
 	credentialsAnnotation               = "externaldns.olm.openshift.io/credentials-secret-hash"
 	trustedCAAnnotation                 = "externaldns.olm.openshift.io/trusted-ca-configmap-hash"
+	infobloxGridCAAnnotation            = "externaldns.olm.openshift.io/infoblox-ca-configmap-hash"
+	txtEncryptionAnnotation             = "externaldns.olm.openshift.io/txt-encryption-secret-hash"
+	operandContainerLabel               = "externaldns.olm.openshift.io/container"
+	operatorAnnotationPrefix            = "externaldns.olm.openshift.io/"
 )
 
 // providerStringTable maps ExternalDNSProviderType values from the
@@ -65,6 +75,7 @@ var providerStringTable = map[operatorv1beta1.ExternalDNSProviderType]string{
 	operatorv1beta1.ProviderTypeAzure:    externalDNSProviderTypeAzure,
 	operatorv1beta1.ProviderTypeBlueCat:  externalDNSProviderTypeBlueCat,
 	operatorv1beta1.ProviderTypeInfoblox: externalDNSProviderTypeInfoblox,
+	operatorv1beta1.ProviderTypeInMemory: externalDNSProviderTypeInMemory,
 }
 
 // sourceStringTable maps ExternalDNSSourceType values from the
@@ -75,27 +86,48 @@ var sourceStringTable = map[operatorv1beta1.ExternalDNSSourceType]string{
 }
 
 type deploymentConfig struct {
-	namespace              string
-	image                  string
-	serviceAccount         *corev1.ServiceAccount
-	externalDNS            *operatorv1beta1.ExternalDNS
-	isOpenShift            bool
-	platformStatus         *configv1.PlatformStatus
-	secret                 string
-	secretHash             string
-	trustedCAConfigMapName string
-	trustedCAConfigMapHash string
-}
-
-// ensureExternalDNSDeployment ensures that the externalDNS deployment exists.
-// Returns a Boolean value indicating whether the deployment exists, a pointer to the deployment, and an error when relevant.
-func (r *reconciler) ensureExternalDNSDeployment(ctx context.Context, namespace, image string, serviceAccount *corev1.ServiceAccount, credSecret *corev1.Secret, trustCAConfigMap *corev1.ConfigMap, externalDNS *operatorv1beta1.ExternalDNS) (bool, *appsv1.Deployment, error) {
-	nsName := types.NamespacedName{Namespace: namespace, Name: controller.ExternalDNSResourceName(externalDNS)}
+	namespace                   string
+	image                       string
+	proxyImage                  string
+	serviceAccount              *corev1.ServiceAccount
+	externalDNS                 *operatorv1beta1.ExternalDNS
+	isOpenShift                 bool
+	platformStatus              *configv1.PlatformStatus
+	secret                      string
+	secretHash                  string
+	trustedCAConfigMapName      string
+	trustedCAConfigMapHash      string
+	infobloxGridCAConfigMapName string
+	infobloxGridCAConfigMapHash string
+	additionalSecretHashes      map[operatorv1beta1.ExternalDNSProviderType]string
+	txtEncryptionSecretName     string
+	txtEncryptionSecretHash     string
+}
+
+// ensureExternalDNSDeployments ensures that the externalDNS deployments exist
+// and deletes the deployments which are not desired anymore.
+// Returns the current deployments, and an error when relevant.
+func (r *reconciler) ensureExternalDNSDeployments(ctx context.Context, namespace, image string, serviceAccount *corev1.ServiceAccount, credSecret *corev1.Secret, additionalCredSecrets map[operatorv1beta1.ExternalDNSProviderType]*corev1.Secret, trustCAConfigMap, infobloxGridCAConfigMap *corev1.ConfigMap, txtEncryptionSecret *corev1.Secret, externalDNS *operatorv1beta1.ExternalDNS) ([]*appsv1.Deployment, error) {
+	var err error
 
 	// build credentials secret's hash
-	credSecretHash, err := buildMapHash(credSecret.Data)
-	if err != nil {
-		return false, nil, fmt.Errorf("failed to build the credentials secret's hash: %w", err)
+	// the secret may be absent for the providers which don't need credentials
+	credSecretName, credSecretHash := "", ""
+	if credSecret != nil {
+		credSecretName = credSecret.Name
+		credSecretHash, err = buildMapHash(credSecret.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the credentials secret's hash: %w", err)
+		}
+	}
+
+	// build credentials secrets' hashes of additional providers
+	additionalCredSecretHashes := map[operatorv1beta1.ExternalDNSProviderType]string{}
+	for providerType, secret := range additionalCredSecrets {
+		additionalCredSecretHashes[providerType], err = buildMapHash(secret.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the credentials secret's hash of %q provider: %w", providerType, err)
+		}
 	}
 
 	// build trusted CA configmap's hash
@@ -104,53 +136,149 @@ func (r *reconciler) ensureExternalDNSDeployment(ctx context.Context, namespace,
 		trustCAConfigMapName = trustCAConfigMap.Name
 		trustCAConfigMapHash, err = buildStringMapHash(trustCAConfigMap.Data)
 		if err != nil {
-			return false, nil, fmt.Errorf("failed to build the CA configmap's hash: %w", err)
+			return nil, fmt.Errorf("failed to build the CA configmap's hash: %w", err)
+		}
+	}
+
+	// build Infoblox grid CA configmap's hash
+	infobloxGridCAConfigMapName, infobloxGridCAConfigMapHash := "", ""
+	if infobloxGridCAConfigMap != nil {
+		infobloxGridCAConfigMapName = infobloxGridCAConfigMap.Name
+		infobloxGridCAConfigMapHash, err = buildStringMapHash(infobloxGridCAConfigMap.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the Infoblox grid CA configmap's hash: %w", err)
 		}
 	}
 
-	desired, err := desiredExternalDNSDeployment(&deploymentConfig{
+	// build TXT encryption secret's hash
+	txtEncryptionSecretName, txtEncryptionSecretHash := "", ""
+	if txtEncryptionSecret != nil {
+		txtEncryptionSecretName = txtEncryptionSecret.Name
+		txtEncryptionSecretHash, err = buildMapHash(txtEncryptionSecret.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the TXT encryption secret's hash: %w", err)
+		}
+	}
+
+	desiredDepls, err := desiredExternalDNSDeployments(&deploymentConfig{
 		namespace,
 		image,
+		r.config.ProxyImage,
 		serviceAccount,
 		externalDNS,
 		r.config.IsOpenShift,
 		r.config.PlatformStatus,
-		credSecret.Name,
+		credSecretName,
 		credSecretHash,
 		trustCAConfigMapName,
 		trustCAConfigMapHash,
+		infobloxGridCAConfigMapName,
+		infobloxGridCAConfigMapHash,
+		additionalCredSecretHashes,
+		txtEncryptionSecretName,
+		txtEncryptionSecretHash,
 	})
 	if err != nil {
-		return false, nil, fmt.Errorf("failed to build externalDNS deployment: %w", err)
+		return nil, fmt.Errorf("failed to build externalDNS deployment: %w", err)
 	}
 
-	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
-		return false, nil, fmt.Errorf("failed to set the controller reference for deployment: %w", err)
+	currentDepls := make([]*appsv1.Deployment, 0, len(desiredDepls))
+	desiredNames := map[string]bool{}
+	for _, desired := range desiredDepls {
+		if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+			return nil, fmt.Errorf("failed to set the controller reference for deployment: %w", err)
+		}
+		current, err := r.ensureExternalDNSDeployment(ctx, desired)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil {
+			currentDepls = append(currentDepls, current)
+		}
+		desiredNames[desired.Name] = true
+	}
+
+	// the deployments of the removed zones or of the previous topology
+	if err := r.deleteStaleExternalDNSDeployments(ctx, namespace, externalDNS, desiredNames); err != nil {
+		return nil, err
 	}
 
+	return currentDepls, nil
+}
+
+// ensureExternalDNSDeployment ensures that the given desired deployment exists.
+// Returns a pointer to the current deployment, and an error when relevant.
+func (r *reconciler) ensureExternalDNSDeployment(ctx context.Context, desired *appsv1.Deployment) (*appsv1.Deployment, error) {
+	nsName := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+
 	exist, current, err := r.currentExternalDNSDeployment(ctx, nsName)
 	if err != nil {
-		return false, nil, fmt.Errorf("failed to get externalDNS deployment: %w", err)
+		return nil, fmt.Errorf("failed to get externalDNS deployment: %w", err)
 	}
 
 	// create the deployment
 	if !exist {
 		if err := r.createExternalDNSDeployment(ctx, desired); err != nil {
-			return false, nil, err
+			return nil, err
 		}
 		// get the deployment from API to catch up the fields added/updated by API and webhooks
-		return r.currentExternalDNSDeployment(ctx, nsName)
+		_, current, err := r.currentExternalDNSDeployment(ctx, nsName)
+		return current, err
 	}
 
 	// update the deployment
 	if updated, err := r.updateExternalDNSDeployment(ctx, current, desired); err != nil {
-		return true, current, err
+		return current, err
 	} else if updated {
 		// get the deployment from API to catch up the fields added/updated by API and webhooks
-		return r.currentExternalDNSDeployment(ctx, nsName)
+		_, current, err := r.currentExternalDNSDeployment(ctx, nsName)
+		return current, err
 	}
 
-	return true, current, nil
+	return current, nil
+}
+
+// deleteStaleExternalDNSDeployments deletes the deployments controlled by the given externalDNS
+// whose names are not in the given set of the desired ones.
+// The pods of the deleted deployments are garbage collected.
+func (r *reconciler) deleteStaleExternalDNSDeployments(ctx context.Context, namespace string, externalDNS *operatorv1beta1.ExternalDNS, desiredNames map[string]bool) error {
+	current, err := r.currentExternalDNSDeployments(ctx, namespace, externalDNS)
+	if err != nil {
+		return fmt.Errorf("failed to get externalDNS deployments: %w", err)
+	}
+	for _, depl := range current {
+		if desiredNames[depl.Name] || !metav1.IsControlledBy(depl, externalDNS) {
+			continue
+		}
+		if err := r.client.Delete(ctx, depl, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete stale externalDNS deployment %s/%s: %w", depl.Namespace, depl.Name, err)
+		}
+		r.log.Info("deleted stale externalDNS deployment", "namespace", depl.Namespace, "name", depl.Name)
+	}
+	return nil
+}
+
+// currentExternalDNSDeployments gets all the current deployments of the given externalDNS:
+// the single deployment and the deployments of the zones.
+func (r *reconciler) currentExternalDNSDeployments(ctx context.Context, namespace string, externalDNS *operatorv1beta1.ExternalDNS) ([]*appsv1.Deployment, error) {
+	depls := []*appsv1.Deployment{}
+
+	exist, depl, err := r.currentExternalDNSDeployment(ctx, types.NamespacedName{Namespace: namespace, Name: controller.ExternalDNSResourceName(externalDNS)})
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		depls = append(depls, depl)
+	}
+
+	zoneDepls := &appsv1.DeploymentList{}
+	if err := r.client.List(ctx, zoneDepls, client.InNamespace(namespace), client.MatchingLabels(operandLabels(externalDNS)), client.HasLabels{operandContainerLabel}); err != nil {
+		return nil, err
+	}
+	for i := range zoneDepls.Items {
+		depls = append(depls, &zoneDepls.Items[i])
+	}
+	return depls, nil
 }
 
 // currentExternalDNSDeployment gets the current externalDNS deployment resource.
@@ -191,15 +319,16 @@ func (r *reconciler) currentExternalDNSTrustedCAConfigMap(ctx context.Context, n
 	return true, cm, nil
 }
 
-// desiredExternalDNSDeployment returns the desired deployment resource.
+// desiredExternalDNSDeployment returns the desired deployment resource with the ExternalDNS containers,
+// the metrics proxies are added by desiredExternalDNSDeployments.
 func desiredExternalDNSDeployment(cfg *deploymentConfig) (*appsv1.Deployment, error) {
 	replicas := int32(1)
-
-	matchLbl := map[string]string{
-		appNameLabel:     controller.ExternalDNSBaseName,
-		appInstanceLabel: cfg.externalDNS.Name,
+	if cfg.externalDNS.Spec.Paused {
+		replicas = 0
 	}
 
+	matchLbl := operandLabels(cfg.externalDNS)
+
 	nodeSelectorLbl := map[string]string{
 		osLabel: linuxOS,
 	}
@@ -212,14 +341,55 @@ func desiredExternalDNSDeployment(cfg *deploymentConfig) (*appsv1.Deployment, er
 		},
 	}
 
-	annotations := map[string]string{
-		credentialsAnnotation: cfg.secretHash,
+	annotations := map[string]string{}
+
+	if cfg.secretHash != "" {
+		annotations[credentialsAnnotation] = cfg.secretHash
 	}
 
 	if cfg.trustedCAConfigMapHash != "" {
 		annotations[trustedCAAnnotation] = cfg.trustedCAConfigMapHash
 	}
 
+	if cfg.infobloxGridCAConfigMapHash != "" {
+		annotations[infobloxGridCAAnnotation] = cfg.infobloxGridCAConfigMapHash
+	}
+
+	if cfg.txtEncryptionSecretHash != "" {
+		annotations[txtEncryptionAnnotation] = cfg.txtEncryptionSecretHash
+	}
+
+	var (
+		affinity                  *corev1.Affinity
+		topologySpreadConstraints []corev1.TopologySpreadConstraint
+		priorityClassName         string
+		writableTmp               bool
+	)
+	if operand := cfg.externalDNS.Spec.OperandDeployment; operand != nil {
+		if operand.NodeSelector != nil {
+			nodeSelectorLbl = operand.NodeSelector
+		}
+		if operand.Tolerations != nil {
+			tolerations = operand.Tolerations
+		}
+		affinity = operand.Affinity
+		topologySpreadConstraints = operand.TopologySpreadConstraints
+		priorityClassName = operand.PriorityClassName
+		writableTmp = operand.WritableTmp
+	}
+
+	podSecurityContext := &corev1.PodSecurityContext{
+		RunAsNonRoot: ptr.To[bool](true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+	// OpenShift's SCC assigns the group from the range of the namespace
+	// and rejects the pods with a group outside of it
+	if !cfg.isOpenShift {
+		podSecurityContext.FSGroup = ptr.To[int64](defaultFSGroup)
+	}
+
 	depl := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      controller.ExternalDNSResourceName(cfg.externalDNS),
@@ -235,18 +405,28 @@ func desiredExternalDNSDeployment(cfg *deploymentConfig) (*appsv1.Deployment, er
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      matchLbl,
+					Labels:      operandLabels(cfg.externalDNS),
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: cfg.serviceAccount.Name,
-					NodeSelector:       nodeSelectorLbl,
-					Tolerations:        tolerations,
+					// ExternalDNS reads its sources from the API server,
+					// the token is mounted explicitly as the service account doesn't automount it
+					AutomountServiceAccountToken: ptr.To[bool](true),
+					SecurityContext:              podSecurityContext,
+					NodeSelector:                 nodeSelectorLbl,
+					Tolerations:                  tolerations,
+					Affinity:                     affinity,
+					TopologySpreadConstraints:    topologySpreadConstraints,
+					PriorityClassName:            priorityClassName,
 				},
 			},
 		},
 	}
 
+	operatorutils.ApplyOperandMetadata(cfg.externalDNS, &depl.ObjectMeta)
+	operatorutils.ApplyOperandMetadata(cfg.externalDNS, &depl.Spec.Template.ObjectMeta)
+
 	provider, ok := providerStringTable[cfg.externalDNS.Spec.Provider.Type]
 	if !ok {
 		return nil, fmt.Errorf("unsupported provider: %q", cfg.externalDNS.Spec.Provider.Type)
@@ -256,26 +436,150 @@ func desiredExternalDNSDeployment(cfg *deploymentConfig) (*appsv1.Deployment, er
 		return nil, fmt.Errorf("unsupported source type: %q", cfg.externalDNS.Spec.Source.Type)
 	}
 
-	vbld := newExternalDNSVolumeBuilder(provider, cfg.secret, cfg.trustedCAConfigMapName)
+	vbld := newExternalDNSVolumeBuilder(provider, cfg.secret, cfg.trustedCAConfigMapName, cfg.infobloxGridCAConfigMapName)
+	vbld.writableTmp = writableTmp
 	volumes := vbld.build()
 	depl.Spec.Template.Spec.Volumes = append(depl.Spec.Template.Spec.Volumes, volumes...)
 
+	image := cfg.image
+	if operand := cfg.externalDNS.Spec.Operand; operand != nil && operand.Image != "" {
+		image = operand.Image
+	}
+
 	cbld := &externalDNSContainerBuilder{
-		image:          cfg.image,
-		provider:       provider,
-		source:         source,
-		secretName:     cfg.secret,
-		volumes:        volumes,
-		externalDNS:    cfg.externalDNS,
-		isOpenShift:    cfg.isOpenShift,
-		platformStatus: cfg.platformStatus,
+		image:                   image,
+		provider:                provider,
+		source:                  source,
+		secretName:              cfg.secret,
+		volumes:                 volumes,
+		externalDNS:             cfg.externalDNS,
+		isOpenShift:             cfg.isOpenShift,
+		platformStatus:          cfg.platformStatus,
+		txtEncryptionSecretName: cfg.txtEncryptionSecretName,
+	}
+
+	containers, err := buildExternalDNSContainers(cbld)
+	if err != nil {
+		return nil, err
 	}
+	depl.Spec.Template.Spec.Containers = append(depl.Spec.Template.Spec.Containers, containers...)
+
+	// additional providers get their own containers in the same pod,
+	// the metrics ports keep incrementing across all the providers
+	for _, target := range cfg.externalDNS.Spec.AdditionalProviders {
+		targetProvider, ok := providerStringTable[target.Type]
+		if !ok {
+			return nil, fmt.Errorf("unsupported provider: %q", target.Type)
+		}
+		targetExtDNS := operatorutils.ExternalDNSForProviderTarget(cfg.externalDNS, target)
+		targetSecret := ""
+		if operatorutils.CredentialsRequiredProvider(targetExtDNS) {
+			targetSecret = controller.ExternalDNSDestProviderCredentialsSecretName(cfg.namespace, cfg.externalDNS.Name, target.Type).Name
+		}
+
+		// the volumes which don't depend on the provider are added only once
+		targetVolumes := newExternalDNSVolumeBuilder(targetProvider, targetSecret, "", "").providerSpecificVolumes()
+		depl.Spec.Template.Spec.Volumes = append(depl.Spec.Template.Spec.Volumes, targetVolumes...)
 
-	if len(cfg.externalDNS.Spec.Zones) == 0 {
+		cbld.provider = targetProvider
+		cbld.providerTarget = target.Type
+		cbld.secretName = targetSecret
+		cbld.volumes = append(volumes, targetVolumes...)
+		cbld.externalDNS = targetExtDNS
+
+		containers, err := buildExternalDNSContainers(cbld)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build containers for %q provider: %w", target.Type, err)
+		}
+		depl.Spec.Template.Spec.Containers = append(depl.Spec.Template.Spec.Containers, containers...)
+
+		if hash := cfg.additionalSecretHashes[target.Type]; hash != "" {
+			depl.Spec.Template.Annotations[providerCredentialsAnnotation(target.Type)] = hash
+		}
+	}
+
+	return depl, nil
+}
+
+// desiredExternalDNSDeployments returns the desired deployment resources
+// according to the deployment topology of the externalDNS.
+// The metrics proxies are added once the containers are split across the deployments.
+func desiredExternalDNSDeployments(cfg *deploymentConfig) ([]*appsv1.Deployment, error) {
+	depl, err := desiredExternalDNSDeployment(cfg)
+	if err != nil {
+		return nil, err
+	}
+	depls := []*appsv1.Deployment{depl}
+	if cfg.externalDNS.Spec.DeploymentTopology == operatorv1beta1.DeploymentTopologyDeploymentPerZone {
+		depls = splitExternalDNSDeploymentPerZone(depl)
+	}
+	for _, depl := range depls {
+		// the service CA of OpenShift issues the certificate for the metrics service
+		servingCertSecretName := ""
+		if cfg.isOpenShift && cfg.externalDNS.Spec.Metrics != nil {
+			servingCertSecretName = metricsServiceName(depl)
+		}
+		addMetricsProxyContainers(depl, cfg.proxyImage, servingCertSecretName)
+	}
+	return depls, nil
+}
+
+// splitExternalDNSDeploymentPerZone returns a deployment for each container of the given deployment.
+// The containers keep their unique metrics ports. The deployments are labeled with the container name
+// which is added to the selector to keep the pods of the deployments apart.
+func splitExternalDNSDeploymentPerZone(depl *appsv1.Deployment) []*appsv1.Deployment {
+	zoneDepls := []*appsv1.Deployment{}
+	byContainer := map[string]*appsv1.Deployment{}
+	for _, container := range depl.Spec.Template.Spec.Containers {
+		// the containers of the public and private Azure zones share the name
+		if zoneDepl, found := byContainer[container.Name]; found {
+			zoneDepl.Spec.Template.Spec.Containers = append(zoneDepl.Spec.Template.Spec.Containers, container)
+			continue
+		}
+
+		zoneDepl := depl.DeepCopy()
+		zoneDepl.Name = controller.ExternalDNSZoneResourceName(depl.Name, container.Name)
+
+		zoneDepl.Labels = withContainerLabel(depl.Spec.Selector.MatchLabels, container.Name)
+		for k, v := range depl.Labels {
+			zoneDepl.Labels[k] = v
+		}
+		zoneDepl.Spec.Selector = &metav1.LabelSelector{MatchLabels: withContainerLabel(depl.Spec.Selector.MatchLabels, container.Name)}
+		zoneDepl.Spec.Template.Labels = withContainerLabel(depl.Spec.Template.Labels, container.Name)
+		zoneDepl.Spec.Template.Spec.Containers = []corev1.Container{container}
+
+		byContainer[container.Name] = zoneDepl
+		zoneDepls = append(zoneDepls, zoneDepl)
+	}
+	return zoneDepls
+}
+
+// withContainerLabel returns a copy of the given labels with the label of the given container.
+func withContainerLabel(labels map[string]string, containerName string) map[string]string {
+	copied := map[string]string{}
+	for k, v := range labels {
+		copied[k] = v
+	}
+	copied[operandContainerLabel] = containerName
+	return copied
+}
+
+// operandLabels returns the labels of the operand pods of the given externalDNS.
+func operandLabels(externalDNS *operatorv1beta1.ExternalDNS) map[string]string {
+	return map[string]string{
+		appNameLabel:     controller.ExternalDNSBaseName,
+		appInstanceLabel: externalDNS.Name,
+	}
+}
+
+// buildExternalDNSContainers returns the containers for all the zones of the builder's ExternalDNS
+func buildExternalDNSContainers(cbld *externalDNSContainerBuilder) ([]corev1.Container, error) {
+	containers := []corev1.Container{}
+	if len(cbld.externalDNS.Spec.Zones) == 0 {
 		// an empty list means publish to all zones
 		// this is a special case for Azure
 		// both public and private zones will need to be published to
-		providerList := []string{provider}
+		providerList := []string{cbld.provider}
 		if cbld.provider == externalDNSProviderTypeAzure {
 			providerList = append(providerList, externalDNSProviderTypeAzurePrivate)
 		}
@@ -285,18 +589,23 @@ func desiredExternalDNSDeployment(cfg *deploymentConfig) (*appsv1.Deployment, er
 			if err != nil {
 				return nil, fmt.Errorf("failed to build container: %w", err)
 			}
-			depl.Spec.Template.Spec.Containers = append(depl.Spec.Template.Spec.Containers, *container)
+			containers = append(containers, *container)
 		}
 	} else {
-		for _, zone := range cfg.externalDNS.Spec.Zones {
+		for _, zone := range cbld.externalDNS.Spec.Zones {
 			container, err := cbld.build(zone)
 			if err != nil {
 				return nil, fmt.Errorf("failed to build container for zone %s: %w", zone, err)
 			}
-			depl.Spec.Template.Spec.Containers = append(depl.Spec.Template.Spec.Containers, *container)
+			containers = append(containers, *container)
 		}
 	}
-	return depl, nil
+	return containers, nil
+}
+
+// providerCredentialsAnnotation returns the annotation with the credentials secret's hash of the given additional provider
+func providerCredentialsAnnotation(providerType operatorv1beta1.ExternalDNSProviderType) string {
+	return credentialsAnnotation + "-" + strings.ToLower(string(providerType))
 }
 
 // createExternalDNSDeployment creates the given deployment using the reconciler's client.
@@ -352,6 +661,14 @@ func externalDNSDeploymentChanged(current, expected *appsv1.Deployment) (bool, *
 		changed = true
 	}
 
+	if operatorutils.OperandMetadataChanged(&current.ObjectMeta, &expected.ObjectMeta, &updated.ObjectMeta) {
+		changed = true
+	}
+
+	if operatorutils.OperandLabelsChanged(&current.Spec.Template.ObjectMeta, &expected.Spec.Template.ObjectMeta, &updated.Spec.Template.ObjectMeta) {
+		changed = true
+	}
+
 	if externalDNSAnnotationsChanged(current, expected, updated) {
 		changed = true
 	}
@@ -364,29 +681,75 @@ func externalDNSDeploymentChanged(current, expected *appsv1.Deployment) (bool, *
 		changed = true
 	}
 
+	if externalDNSSchedulingChanged(current, expected, updated) {
+		changed = true
+	}
+
+	if externalDNSPodSecurityChanged(current, expected, updated) {
+		changed = true
+	}
+
 	return changed, updated
 }
 
-// externalDNSAnnotationsChanged returns true if any annotation from the podspec differs from the expected.```
-func externalDNSAnnotationsChanged(current, expected, updated *appsv1.Deployment) bool {
+// externalDNSPodSecurityChanged returns true if the security settings of the current podspec differ from the expected.
+func externalDNSPodSecurityChanged(current, expected, updated *appsv1.Deployment) bool {
 	changed := false
-	if len(current.Spec.Template.Annotations) == 0 {
-		if len(expected.Spec.Template.Annotations) == 0 {
-			return false
-		}
-		updated.Spec.Template.Annotations = expected.Spec.Template.Annotations
-		return true
+	currentSpec, expectedSpec, updatedSpec := &current.Spec.Template.Spec, &expected.Spec.Template.Spec, &updated.Spec.Template.Spec
+
+	if !equalBoolPtr(currentSpec.AutomountServiceAccountToken, expectedSpec.AutomountServiceAccountToken) {
+		updatedSpec.AutomountServiceAccountToken = expectedSpec.AutomountServiceAccountToken
+		changed = true
 	}
-	for expectedKey, expectedValue := range expected.Spec.Template.Annotations {
-		currentVal, currentExists := current.Spec.Template.Annotations[expectedKey]
-		if !currentExists || currentVal != expectedValue {
-			updated.Spec.Template.Annotations[expectedKey] = expectedValue
-			changed = true
-		}
+	if scChanged, updatedContext := podSecurityContextChanged(currentSpec.SecurityContext, updatedSpec.SecurityContext, expectedSpec.SecurityContext); scChanged {
+		updatedSpec.SecurityContext = updatedContext
+		changed = true
 	}
+
 	return changed
 }
 
+// externalDNSSchedulingChanged returns true if the scheduling constraints of the current podspec differ from the expected.
+func externalDNSSchedulingChanged(current, expected, updated *appsv1.Deployment) bool {
+	changed := false
+	currentSpec, expectedSpec, updatedSpec := &current.Spec.Template.Spec, &expected.Spec.Template.Spec, &updated.Spec.Template.Spec
+
+	if !equality.Semantic.DeepEqual(currentSpec.NodeSelector, expectedSpec.NodeSelector) {
+		updatedSpec.NodeSelector = expectedSpec.NodeSelector
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(currentSpec.Tolerations, expectedSpec.Tolerations) {
+		updatedSpec.Tolerations = expectedSpec.Tolerations
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(currentSpec.Affinity, expectedSpec.Affinity) {
+		updatedSpec.Affinity = expectedSpec.Affinity
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(currentSpec.TopologySpreadConstraints, expectedSpec.TopologySpreadConstraints) {
+		updatedSpec.TopologySpreadConstraints = expectedSpec.TopologySpreadConstraints
+		changed = true
+	}
+	if currentSpec.PriorityClassName != expectedSpec.PriorityClassName {
+		updatedSpec.PriorityClassName = expectedSpec.PriorityClassName
+		changed = true
+	}
+
+	return changed
+}
+
+// externalDNSAnnotationsChanged returns true if any annotation from the podspec differs from the expected.
+// The annotations previously set by the operator and no longer expected are removed,
+// e.g. the hash of the TXT encryption secret once the encryption is disabled.
+func externalDNSAnnotationsChanged(current, expected, updated *appsv1.Deployment) bool {
+	return operatorutils.OperandAnnotationsChanged(&current.Spec.Template.ObjectMeta, &expected.Spec.Template.ObjectMeta, &updated.Spec.Template.ObjectMeta, isOperatorAnnotation)
+}
+
+// isOperatorAnnotation returns true if the given annotation of the operand pods is set by the operator.
+func isOperatorAnnotation(key string) bool {
+	return strings.HasPrefix(key, operatorAnnotationPrefix)
+}
+
 // externalDNSContainersChanged returns true if the current containers differ from the expected.
 func externalDNSContainersChanged(current, expected, updated *appsv1.Deployment) bool {
 	changed := false
@@ -395,9 +758,10 @@ func externalDNSContainersChanged(current, expected, updated *appsv1.Deployment)
 	expectedContMap := buildIndexedContainerMap(expected.Spec.Template.Spec.Containers)
 
 	// ensure all expected containers are present,
-	for expName, expCont := range expectedContMap {
+	// the missing ones are added in the expected order
+	for _, expCont := range expected.Spec.Template.Spec.Containers {
 		// expected container is present
-		if currCont, found := currentContMap[expName]; found {
+		if currCont, found := currentContMap[expCont.Name]; found {
 			if currCont.Image != expCont.Image {
 				updated.Spec.Template.Spec.Containers[currCont.Index].Image = expCont.Image
 				changed = true
@@ -406,6 +770,10 @@ func externalDNSContainersChanged(current, expected, updated *appsv1.Deployment)
 				updated.Spec.Template.Spec.Containers[currCont.Index].Args = expCont.Args
 				changed = true
 			}
+			if !equality.Semantic.DeepEqual(currCont.Resources, expCont.Resources) {
+				updated.Spec.Template.Spec.Containers[currCont.Index].Resources = expCont.Resources
+				changed = true
+			}
 			if !equalEnvVars(currCont.Env, expCont.Env) {
 				updated.Spec.Template.Spec.Containers[currCont.Index].Env = expCont.Env
 				changed = true
@@ -414,13 +782,21 @@ func externalDNSContainersChanged(current, expected, updated *appsv1.Deployment)
 				updated.Spec.Template.Spec.Containers[currCont.Index].VolumeMounts = updatedVolumeMounts
 				changed = true
 			}
+			if !equality.Semantic.DeepEqual(currCont.LivenessProbe, expCont.LivenessProbe) {
+				updated.Spec.Template.Spec.Containers[currCont.Index].LivenessProbe = expCont.LivenessProbe
+				changed = true
+			}
+			if !equality.Semantic.DeepEqual(currCont.ReadinessProbe, expCont.ReadinessProbe) {
+				updated.Spec.Template.Spec.Containers[currCont.Index].ReadinessProbe = expCont.ReadinessProbe
+				changed = true
+			}
 			if scChanged, updatedContext := securityContextChanged(currCont.SecurityContext, updated.Spec.Template.Spec.Containers[currCont.Index].SecurityContext, expCont.SecurityContext); scChanged {
 				updated.Spec.Template.Spec.Containers[currCont.Index].SecurityContext = updatedContext
 				changed = true
 			}
 		} else {
 			// expected container is not present - add it
-			updated.Spec.Template.Spec.Containers = append(updated.Spec.Template.Spec.Containers, expCont.Container)
+			updated.Spec.Template.Spec.Containers = append(updated.Spec.Template.Spec.Containers, expCont)
 			changed = true
 		}
 	}
@@ -440,6 +816,21 @@ func externalDNSContainersChanged(current, expected, updated *appsv1.Deployment)
 	return changed
 }
 
+// operandVolumeNames are the names of the volumes the operator may add to the operand pods.
+// These volumes and their mounts are removed once they are no longer expected,
+// the volumes added by others are kept.
+var operandVolumeNames = map[string]struct{}{
+	trustedCAVolumeName:          {},
+	tmpVolumeName:                {},
+	metricsServingCertVolumeName: {},
+	awsCredentialsVolumeName:     {},
+	boundSATokenVolumeName:       {},
+	azureConfigVolumeName:        {},
+	gcpCredentialsVolumeName:     {},
+	blueCatConfigVolumeName:      {},
+	infobloxGridCAVolumeName:     {},
+}
+
 // externalDNSVolumesChanged returns true if the current volumes differ from the expected.
 func externalDNSVolumesChanged(current, expected, updated *appsv1.Deployment) bool {
 	if len(current.Spec.Template.Spec.Volumes) == 0 {
@@ -473,9 +864,27 @@ func externalDNSVolumesChanged(current, expected, updated *appsv1.Deployment) bo
 		}
 	}
 
+	// remove the operand volumes which are no longer expected
+	updatedNew := []corev1.Volume{}
+	for _, updVol := range updated.Spec.Template.Spec.Volumes {
+		if _, expected := expectedVolumeMap[updVol.Name]; expected || !isOperandVolume(updVol.Name) {
+			updatedNew = append(updatedNew, updVol)
+		}
+	}
+	if len(updated.Spec.Template.Spec.Volumes) != len(updatedNew) {
+		updated.Spec.Template.Spec.Volumes = updatedNew
+		changed = true
+	}
+
 	return changed
 }
 
+// isOperandVolume returns true if the volume with the given name is managed by the operator.
+func isOperandVolume(name string) bool {
+	_, found := operandVolumeNames[name]
+	return found
+}
+
 // externalDNSDeploymentStrategyChanged returns true if the current update strategy differs from expected.
 func externalDNSDeploymentStrategyChanged(current, expected, updated *appsv1.Deployment) bool {
 	changed := false
@@ -527,6 +936,18 @@ func volumeMountsChanged(current, expected, updated []corev1.VolumeMount) (bool,
 		}
 	}
 
+	// remove the mounts of the operand volumes which are no longer expected
+	updatedNew := []corev1.VolumeMount{}
+	for _, updVol := range updated {
+		if _, expected := expectedVolumeMountMap[updVol.Name]; expected || !isOperandVolume(updVol.Name) {
+			updatedNew = append(updatedNew, updVol)
+		}
+	}
+	if len(updated) != len(updatedNew) {
+		updated = updatedNew
+		changed = true
+	}
+
 	return changed, updated
 }
 
@@ -688,19 +1109,63 @@ func securityContextChanged(current, updated, desired *corev1.SecurityContext) (
 		changed = true
 	}
 
-	if desired.SeccompProfile != nil {
-		if current.SeccompProfile == nil {
-			updated.SeccompProfile = desired.SeccompProfile
-			changed = true
-		} else if desired.SeccompProfile.Type != "" && desired.SeccompProfile.Type != current.SeccompProfile.Type {
-			updated.SeccompProfile = desired.SeccompProfile
-			changed = true
-		}
+	if !equalBoolPtr(current.ReadOnlyRootFilesystem, desired.ReadOnlyRootFilesystem) {
+		updated.ReadOnlyRootFilesystem = desired.ReadOnlyRootFilesystem
+		changed = true
+	}
+
+	if seccompProfileChanged(current.SeccompProfile, desired.SeccompProfile) {
+		updated.SeccompProfile = desired.SeccompProfile
+		changed = true
 	}
 
 	return changed, updated
 }
 
+// podSecurityContextChanged checks that the current pod security context matches the desired one.
+// Only the fields set in the desired context are compared,
+// the ones set by others (e.g. OpenShift's SCC) are kept.
+// Returns a Boolean value indicating whether the context has to be updated, and the updated context.
+func podSecurityContextChanged(current, updated, desired *corev1.PodSecurityContext) (bool, *corev1.PodSecurityContext) {
+	changed := false
+
+	if desired == nil {
+		return false, nil
+	}
+
+	if updated == nil || current == nil {
+		return true, desired
+	}
+
+	if !equalBoolPtr(current.RunAsNonRoot, desired.RunAsNonRoot) {
+		updated.RunAsNonRoot = desired.RunAsNonRoot
+		changed = true
+	}
+
+	if desired.FSGroup != nil && (current.FSGroup == nil || *current.FSGroup != *desired.FSGroup) {
+		updated.FSGroup = desired.FSGroup
+		changed = true
+	}
+
+	if seccompProfileChanged(current.SeccompProfile, desired.SeccompProfile) {
+		updated.SeccompProfile = desired.SeccompProfile
+		changed = true
+	}
+
+	return changed, updated
+}
+
+// seccompProfileChanged returns true if the current seccomp profile differs from the desired one.
+func seccompProfileChanged(current, desired *corev1.SeccompProfile) bool {
+	if desired == nil {
+		return false
+	}
+	if current == nil {
+		return true
+	}
+	return desired.Type != "" && desired.Type != current.Type
+}
+
 func equalBoolPtr(current, desired *bool) bool {
 	if desired == nil {
 		return true
@@ -0,0 +1,389 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+const (
+	// dryRunLogsPeriod is the period of the operand logs from which the planned changes are collected.
+	// It covers a couple of synchronizations with the default interval of 1 minute.
+	dryRunLogsPeriod = 2 * time.Minute
+	// dryRunCheckPeriod is the period of the collection of the planned changes.
+	dryRunCheckPeriod = 1 * time.Minute
+
+	dryRunCreatesKey = "creates"
+	dryRunUpdatesKey = "updates"
+	dryRunDeletesKey = "deletes"
+	// dryRunUnrecognizedKey lists the containers whose logs didn't show any recognized planning output.
+	dryRunUnrecognizedKey = "unrecognizedContainers"
+
+	// dryRunUnrecognizedEventReason is the reason of the event emitted when the planned changes cannot be recognized.
+	dryRunUnrecognizedEventReason = "UnrecognizedPlan"
+)
+
+var (
+	// AWS: Desired change: CREATE foo.example.com A [Id: /hostedzone/Z3URY6TWQ91KXX]
+	dryRunDesiredChangeRegexp = regexp.MustCompile(`Desired change: (CREATE|UPSERT|DELETE) (\S+) (\S+)`)
+	// Azure, Infoblox, BlueCat: Would create A record named 'foo' to '10.0.0.1' for Azure DNS zone 'example.com'.
+	// The applied changes are logged as "Creating", "Updating" and "Deleting" outside of the dry run mode.
+	// Azure logs the record names relative to the zone, "@" being the apex of the zone.
+	dryRunWouldChangeRegexp = regexp.MustCompile(`(?i)(would create|would update|would delete|creating|updating|deleting) (\S+) record named '([^']+)'(?:.* zone '([^']+)')?`)
+	// GCP: Add records: foo.example.com. A [10.0.0.1] 300
+	dryRunRecordsChangeRegexp = regexp.MustCompile(`(Add|Del) records: (\S+) (\S+)`)
+	// All providers: logged by ExternalDNS when no changes are planned.
+	dryRunUpToDateRegexp = regexp.MustCompile(`All records are already up to date`)
+)
+
+// podLogsReader reads the logs of the containers of the operand pods.
+type podLogsReader interface {
+	// ReadPodLogs returns the logs of the given container written during the given period,
+	// all the logs if the period is zero.
+	ReadPodLogs(ctx context.Context, pod types.NamespacedName, container string, since time.Duration) (io.ReadCloser, error)
+}
+
+// clientsetPodLogsReader reads the pod logs using the typed client
+// as the controller-runtime client doesn't support the log subresource.
+type clientsetPodLogsReader struct {
+	pods corev1client.PodsGetter
+}
+
+// ReadPodLogs implements podLogsReader.
+func (r *clientsetPodLogsReader) ReadPodLogs(ctx context.Context, pod types.NamespacedName, container string, since time.Duration) (io.ReadCloser, error) {
+	opts := &corev1.PodLogOptions{
+		Container: container,
+	}
+	if since > 0 {
+		sinceSeconds := int64(since.Seconds())
+		opts.SinceSeconds = &sinceSeconds
+	}
+	return r.pods.Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+}
+
+// dryRunPlan is the set of the DNS record changes planned by ExternalDNS.
+// The records are identified by their fully qualified name and type.
+type dryRunPlan struct {
+	creates map[string]struct{}
+	updates map[string]struct{}
+	deletes map[string]struct{}
+	// recognized is true once any planning output was found in the logs,
+	// the plan of a provider which doesn't log its changes stays unrecognized rather than empty.
+	recognized bool
+	// unrecognizedContainers are the containers whose plans were not recognized, set on the merged plans only.
+	unrecognizedContainers []string
+}
+
+func newDryRunPlan() *dryRunPlan {
+	return &dryRunPlan{
+		creates: map[string]struct{}{},
+		updates: map[string]struct{}{},
+		deletes: map[string]struct{}{},
+	}
+}
+
+// parseLogs adds the changes found in the given ExternalDNS logs to the plan.
+// Only the providers which log the planned changes in the dry run mode are supported.
+func (p *dryRunPlan) parseLogs(logs io.Reader) error {
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := dryRunDesiredChangeRegexp.FindStringSubmatch(line); m != nil {
+			p.recognized = true
+			switch m[1] {
+			case "CREATE":
+				p.add(p.creates, m[2], m[3])
+			case "UPSERT":
+				p.add(p.updates, m[2], m[3])
+			case "DELETE":
+				p.add(p.deletes, m[2], m[3])
+			}
+		} else if m := dryRunWouldChangeRegexp.FindStringSubmatch(line); m != nil {
+			p.recognized = true
+			name := qualifyDryRunRecordName(m[3], m[4])
+			switch strings.ToLower(m[1]) {
+			case "would create", "creating":
+				p.add(p.creates, name, m[2])
+			case "would update", "updating":
+				p.add(p.updates, name, m[2])
+			case "would delete", "deleting":
+				p.add(p.deletes, name, m[2])
+			}
+		} else if m := dryRunRecordsChangeRegexp.FindStringSubmatch(line); m != nil {
+			p.recognized = true
+			switch m[1] {
+			case "Add":
+				p.add(p.creates, m[2], m[3])
+			case "Del":
+				p.add(p.deletes, m[2], m[3])
+			}
+		} else if dryRunUpToDateRegexp.MatchString(line) {
+			p.recognized = true
+		}
+	}
+	return scanner.Err()
+}
+
+// qualifyDryRunRecordName returns the fully qualified name of the given record of the given zone.
+// The names which are already qualified are returned as is.
+func qualifyDryRunRecordName(name, zone string) string {
+	name, zone = strings.TrimSuffix(name, "."), strings.TrimSuffix(zone, ".")
+	switch {
+	case zone == "" || name == zone || strings.HasSuffix(name, "."+zone):
+		return name
+	case name == "@":
+		return zone
+	}
+	return name + "." + zone
+}
+
+func (p *dryRunPlan) add(changes map[string]struct{}, name, recordType string) {
+	changes[strings.TrimSuffix(name, ".")+" "+recordType] = struct{}{}
+}
+
+// mergeDryRunPlans returns the plan with the changes of all the given plans keyed by the container name,
+// the containers whose plans were not recognized are listed in the returned plan.
+func mergeDryRunPlans(containerPlans map[string]*dryRunPlan) *dryRunPlan {
+	plan := newDryRunPlan()
+	for containerName, containerPlan := range containerPlans {
+		plan.merge(containerPlan)
+		if !containerPlan.recognized {
+			plan.unrecognizedContainers = append(plan.unrecognizedContainers, containerName)
+		}
+	}
+	sort.Strings(plan.unrecognizedContainers)
+	return plan
+}
+
+// merge adds the changes of the given plan to the plan.
+func (p *dryRunPlan) merge(other *dryRunPlan) {
+	for change := range other.creates {
+		p.creates[change] = struct{}{}
+	}
+	for change := range other.updates {
+		p.updates[change] = struct{}{}
+	}
+	for change := range other.deletes {
+		p.deletes[change] = struct{}{}
+	}
+}
+
+// changes returns the sorted changes of the plan.
+func (p *dryRunPlan) changes() operatorv1beta1.DNSChanges {
+	return operatorv1beta1.DNSChanges{
+		Creates: sortedDryRunChanges(p.creates),
+		Updates: sortedDryRunChanges(p.updates),
+		Deletes: sortedDryRunChanges(p.deletes),
+	}
+}
+
+// ensureExternalDNSDryRunConfigMap ensures that the changes planned by the operand in the dry run mode
+// are listed in the configmap in the operand namespace, the configmap is removed once the dry run is turned off.
+func (r *reconciler) ensureExternalDNSDryRunConfigMap(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, deployments []*appsv1.Deployment) error {
+	nsName := controller.ExternalDNSDestDryRunConfigMapName(r.config.Namespace, externalDNS.Name)
+
+	exists, current, err := r.currentExternalDNSConfigMap(ctx, nsName)
+	if err != nil {
+		return fmt.Errorf("failed to get the dry run configmap %s: %w", nsName, err)
+	}
+
+	if !externalDNS.Spec.DryRun {
+		if exists {
+			if err := r.client.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete the dry run configmap %s: %w", nsName, err)
+			}
+			r.log.Info("deleted externalDNS configmap", "namespace", nsName.Namespace, "name", nsName.Name)
+		}
+		return nil
+	}
+
+	plan, collected, err := r.collectDryRunPlan(ctx, deployments)
+	if err != nil {
+		return err
+	}
+	if !collected && exists {
+		// no operand is running at the moment,
+		// keep the previously collected plan
+		return nil
+	}
+	if len(plan.unrecognizedContainers) > 0 {
+		r.recorder.Eventf(externalDNS, corev1.EventTypeWarning, dryRunUnrecognizedEventReason, "The planned changes of containers %s could not be recognized in their logs", strings.Join(plan.unrecognizedContainers, ", "))
+	}
+
+	desired := desiredExternalDNSDryRunConfigMap(plan, nsName)
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return fmt.Errorf("failed to set the controller reference for dry run configmap: %w", err)
+	}
+
+	if !exists {
+		return r.createExternalDNSConfigMap(ctx, desired)
+	}
+	_, err = r.updateExternalDNSConfigMap(ctx, current, desired)
+	return err
+}
+
+// collectDryRunPlan collects the planned changes from the logs of all the containers of the running operand pods.
+// Returns the plan, a Boolean value indicating whether any logs were read, and an error when relevant.
+// The containers whose logs showed no recognized planning output are listed in the plan.
+func (r *reconciler) collectDryRunPlan(ctx context.Context, deployments []*appsv1.Deployment) (*dryRunPlan, bool, error) {
+	containerPlans, err := r.collectContainerPlans(ctx, deployments)
+	if err != nil {
+		return nil, false, err
+	}
+	return mergeDryRunPlans(containerPlans), len(containerPlans) > 0, nil
+}
+
+// collectContainerPlans collects the changes from the logs of each container of the running operand pods.
+// Returns the plans keyed by the container name, only the containers whose logs were read are present.
+func (r *reconciler) collectContainerPlans(ctx context.Context, deployments []*appsv1.Deployment) (map[string]*dryRunPlan, error) {
+	plans := map[string]*dryRunPlan{}
+	for _, deployment := range deployments {
+		if err := r.collectDeploymentContainerPlans(ctx, deployment, plans); err != nil {
+			return nil, err
+		}
+	}
+	return plans, nil
+}
+
+// collectDeploymentContainerPlans collects the changes from the logs of each container
+// of the running pods of the given deployment into the given plans keyed by the container name.
+func (r *reconciler) collectDeploymentContainerPlans(ctx context.Context, deployment *appsv1.Deployment, plans map[string]*dryRunPlan) error {
+	if deployment == nil || deployment.Spec.Selector == nil {
+		return nil
+	}
+	return r.collectPodsContainerPlans(ctx, deployment.Namespace, deployment.Spec.Selector.MatchLabels, corev1.PodRunning, dryRunLogsPeriod, plans)
+}
+
+// collectPodsContainerPlans collects the changes from the logs written during the given period
+// by each container of the pods with the given labels and phase
+// into the given plans keyed by the container name.
+func (r *reconciler) collectPodsContainerPlans(ctx context.Context, namespace string, labels map[string]string, phase corev1.PodPhase, since time.Duration, plans map[string]*dryRunPlan) error {
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return fmt.Errorf("failed to list the pods with labels %v in namespace %s: %w", labels, namespace, err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != phase {
+			continue
+		}
+		podName := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+		for _, container := range operandContainers(pod.Spec.Containers) {
+			logs, err := r.podLogs.ReadPodLogs(ctx, podName, container.Name, since)
+			if err != nil {
+				// the container may be restarting, the next collection will pick it up
+				r.log.Info("failed to read the logs of externalDNS container", "pod", podName, "container", container.Name, "error", err.Error())
+				continue
+			}
+			plan, found := plans[container.Name]
+			if !found {
+				plan = newDryRunPlan()
+				plans[container.Name] = plan
+			}
+			err = plan.parseLogs(logs)
+			logs.Close()
+			if err != nil {
+				return fmt.Errorf("failed to parse the logs of container %q of pod %s: %w", container.Name, podName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// desiredExternalDNSDryRunConfigMap returns the desired configmap listing the planned changes.
+func desiredExternalDNSDryRunConfigMap(plan *dryRunPlan, nsName types.NamespacedName) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: nsName.Namespace,
+			Name:      nsName.Name,
+		},
+		Data: map[string]string{
+			dryRunCreatesKey:      joinDryRunChanges(plan.creates),
+			dryRunUpdatesKey:      joinDryRunChanges(plan.updates),
+			dryRunDeletesKey:      joinDryRunChanges(plan.deletes),
+			dryRunUnrecognizedKey: strings.Join(plan.unrecognizedContainers, "\n"),
+		},
+	}
+}
+
+// joinDryRunChanges returns the sorted changes, one per line.
+func joinDryRunChanges(changes map[string]struct{}) string {
+	return strings.Join(sortedDryRunChanges(changes), "\n")
+}
+
+// sortedDryRunChanges returns the sorted list of the given changes.
+func sortedDryRunChanges(changes map[string]struct{}) []string {
+	sorted := make([]string, 0, len(changes))
+	for change := range changes {
+		sorted = append(sorted, change)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// countDryRunChanges returns the number of the changes listed under the given key of the dry run configmap.
+func countDryRunChanges(cm *corev1.ConfigMap, key string) int32 {
+	if len(cm.Data[key]) == 0 {
+		return 0
+	}
+	return int32(len(strings.Split(cm.Data[key], "\n")))
+}
+
+// computeDryRunStatus returns the summary of the changes listed in the dry run configmap,
+// nil if the dry run is turned off or no changes were collected yet.
+func (r *reconciler) computeDryRunStatus(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) *operatorv1beta1.ExternalDNSDryRunStatus {
+	if !externalDNS.Spec.DryRun {
+		return nil
+	}
+	nsName := controller.ExternalDNSDestDryRunConfigMapName(r.config.Namespace, externalDNS.Name)
+	exists, cm, err := r.currentExternalDNSConfigMap(ctx, nsName)
+	if err != nil {
+		r.log.Error(err, "failed to get the dry run configmap", "configmap", nsName)
+		return nil
+	} else if !exists {
+		return nil
+	}
+	status := &operatorv1beta1.ExternalDNSDryRunStatus{
+		ConfigMapName: nsName.Name,
+		Creates:       countDryRunChanges(cm, dryRunCreatesKey),
+		Updates:       countDryRunChanges(cm, dryRunUpdatesKey),
+		Deletes:       countDryRunChanges(cm, dryRunDeletesKey),
+	}
+	if unrecognized := cm.Data[dryRunUnrecognizedKey]; len(unrecognized) != 0 {
+		status.Message = fmt.Sprintf("The planned changes of containers %s could not be recognized in their logs, the counts may be incomplete.", strings.ReplaceAll(unrecognized, "\n", ", "))
+	}
+	return status
+}
@@ -0,0 +1,397 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+const (
+	testDryRunConfigMapName = "external-dns-dry-run-test"
+	testDryRunPodName       = "external-dns-test-7d4f8b9c5-x2k4p"
+)
+
+// fakePodLogsReader returns the logs from the map keyed by pod name and container name.
+type fakePodLogsReader map[string]string
+
+func (r fakePodLogsReader) ReadPodLogs(_ context.Context, pod types.NamespacedName, container string, _ time.Duration) (io.ReadCloser, error) {
+	logs, found := r[pod.Name+"/"+container]
+	if !found {
+		return nil, fmt.Errorf("container %q is waiting to start", container)
+	}
+	return io.NopCloser(strings.NewReader(logs)), nil
+}
+
+func TestDryRunPlanParseLogs(t *testing.T) {
+	testCases := []struct {
+		name               string
+		logs               string
+		expectedRecognized bool
+		expectedCreates    string
+		expectedUpdates    string
+		expectedDeletes    string
+	}{
+		{
+			name: "AWS",
+			logs: `time="2024-05-14T10:00:00Z" level=info msg="Applying provider record filter for domains: [test.com. .test.com.]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: CREATE app.test.com A [Id: /hostedzone/Z3URY6TWQ91KXX]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: CREATE external-dns-a-app.test.com TXT [Id: /hostedzone/Z3URY6TWQ91KXX]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: UPSERT api.test.com CNAME [Id: /hostedzone/Z3URY6TWQ91KXX]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: DELETE old.test.com A [Id: /hostedzone/Z3URY6TWQ91KXX]"
+time="2024-05-14T10:00:01Z" level=info msg="4 record(s) in zone test.com. [Id: /hostedzone/Z3URY6TWQ91KXX] would be updated"
+time="2024-05-14T10:01:01Z" level=info msg="Desired change: CREATE app.test.com A [Id: /hostedzone/Z3URY6TWQ91KXX]"`,
+			expectedRecognized: true,
+			expectedCreates:    "app.test.com A\nexternal-dns-a-app.test.com TXT",
+			expectedUpdates:    "api.test.com CNAME",
+			expectedDeletes:    "old.test.com A",
+		},
+		{
+			name: "Azure",
+			logs: `time="2024-05-14T10:00:00Z" level=info msg="Would create A record named 'web' to '[10.0.0.1]' for Azure DNS zone 'test.com'."
+time="2024-05-14T10:00:00Z" level=info msg="Would create TXT record named 'external-dns-a-web' to '[\"heritage=external-dns,external-dns/owner=test\"]' for Azure DNS zone 'test.com'."
+time="2024-05-14T10:00:00Z" level=info msg="Would update A record named '@' to '[10.0.0.2]' for Azure DNS zone 'test.com'."
+time="2024-05-14T10:00:00Z" level=info msg="Would delete A record named 'gone' for Azure DNS zone 'test.com'."
+time="2024-05-14T10:01:00Z" level=info msg="Deleting TXT record named 'external-dns-a-gone' for Azure DNS zone 'test.com'."`,
+			expectedRecognized: true,
+			expectedCreates:    "external-dns-a-web.test.com TXT\nweb.test.com A",
+			expectedUpdates:    "test.com A",
+			expectedDeletes:    "external-dns-a-gone.test.com TXT\ngone.test.com A",
+		},
+		{
+			name: "GCP",
+			logs: `time="2024-05-14T10:00:00Z" level=info msg="Change zone: test-com batch #0"
+time="2024-05-14T10:00:00Z" level=info msg="Del records: legacy.test.com. CNAME [old.test.com.] 300"
+time="2024-05-14T10:00:00Z" level=info msg="Add records: db.test.com. A [10.0.0.2] 300"
+time="2024-05-14T10:00:00Z" level=info msg="Add records: external-dns-a-db.test.com. TXT [\"heritage=external-dns,external-dns/owner=test\"] 300"`,
+			expectedRecognized: true,
+			expectedCreates:    "db.test.com A\nexternal-dns-a-db.test.com TXT",
+			expectedDeletes:    "legacy.test.com CNAME",
+		},
+		{
+			name: "Infoblox",
+			logs: `time="2024-05-14T10:00:00Z" level=info msg="Would create A record named 'web.test.com' to '10.0.0.1' for Infoblox DNS zone 'test.com'."
+time="2024-05-14T10:00:00Z" level=info msg="Would delete A record named 'gone.test.com' to '10.0.0.3' for Infoblox DNS zone 'test.com'."`,
+			expectedRecognized: true,
+			expectedCreates:    "web.test.com A",
+			expectedDeletes:    "gone.test.com A",
+		},
+		{
+			name: "BlueCat",
+			logs: `time="2024-05-14T10:00:00Z" level=info msg="would create A record named 'web.test.com' to '[10.0.0.1]' for BlueCat DNS zone 'test.com'."
+time="2024-05-14T10:00:00Z" level=info msg="would delete A record named 'gone.test.com' for BlueCat DNS zone 'test.com'."`,
+			expectedRecognized: true,
+			expectedCreates:    "web.test.com A",
+			expectedDeletes:    "gone.test.com A",
+		},
+		{
+			name: "Nothing is planned",
+			logs: `time="2024-05-14T10:00:00Z" level=info msg="Applying provider record filter for domains: [test.com. .test.com.]"
+time="2024-05-14T10:00:01Z" level=info msg="All records are already up to date"`,
+			expectedRecognized: true,
+		},
+		{
+			name: "InMemory doesn't log the planned changes",
+			logs: `time="2024-05-14T10:00:00Z" level=info msg="Instantiating new Kubernetes client"
+time="2024-05-14T10:00:00Z" level=info msg="Using inCluster-config based on serviceaccount-token"
+time="2024-05-14T10:00:00Z" level=info msg="Created Kubernetes client https://172.30.0.1:443"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := newDryRunPlan()
+			if err := plan.parseLogs(strings.NewReader(tc.logs)); err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+			if plan.recognized != tc.expectedRecognized {
+				t.Errorf("expected the plan to be recognized %t, got %t", tc.expectedRecognized, plan.recognized)
+			}
+			if got := joinDryRunChanges(plan.creates); got != tc.expectedCreates {
+				t.Errorf("expected creates %q, got %q", tc.expectedCreates, got)
+			}
+			if got := joinDryRunChanges(plan.updates); got != tc.expectedUpdates {
+				t.Errorf("expected updates %q, got %q", tc.expectedUpdates, got)
+			}
+			if got := joinDryRunChanges(plan.deletes); got != tc.expectedDeletes {
+				t.Errorf("expected deletes %q, got %q", tc.expectedDeletes, got)
+			}
+		})
+	}
+}
+
+func TestEnsureExternalDNSDryRunConfigMap(t *testing.T) {
+	ownerRefs := []metav1.OwnerReference{
+		{
+			APIVersion:         operatorv1beta1.GroupVersion.String(),
+			Kind:               "ExternalDNS",
+			Name:               test.Name,
+			Controller:         &test.TrueVar,
+			BlockOwnerDeletion: &test.TrueVar,
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      test.OperandName,
+			Namespace: test.OperandNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					appNameLabel:     ExternalDNSBaseName,
+					appInstanceLabel: test.Name,
+				},
+			},
+		},
+	}
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDryRunPodName,
+			Namespace: test.OperandNamespace,
+			Labels: map[string]string{
+				appNameLabel:     ExternalDNSBaseName,
+				appInstanceLabel: test.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "external-dns-zone1"},
+				{Name: "external-dns-zone2"},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	existingCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testDryRunConfigMapName,
+			Namespace:       test.OperandNamespace,
+			OwnerReferences: ownerRefs,
+		},
+		Data: map[string]string{
+			dryRunCreatesKey: "previous.test.com A",
+			dryRunUpdatesKey: "",
+			dryRunDeletesKey: "",
+		},
+	}
+	podLogs := fakePodLogsReader{
+		testDryRunPodName + "/external-dns-zone1": `time="2024-05-14T10:00:01Z" level=info msg="Desired change: CREATE app.test.com A [Id: /hostedzone/zone1]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: DELETE old.test.com A [Id: /hostedzone/zone1]"`,
+		testDryRunPodName + "/external-dns-zone2": `time="2024-05-14T10:00:01Z" level=info msg="Desired change: CREATE app.test.com A [Id: /hostedzone/zone2]"
+time="2024-05-14T10:00:01Z" level=info msg="Desired change: UPSERT api.test.com CNAME [Id: /hostedzone/zone2]"`,
+	}
+
+	testCases := []struct {
+		name            string
+		dryRun          bool
+		existingObjects []runtime.Object
+		podLogs         fakePodLogsReader
+		expectedCM      *corev1.ConfigMap
+		expectedEvent   bool
+	}{
+		{
+			name:            "Dry run is off",
+			existingObjects: []runtime.Object{runningPod},
+			podLogs:         podLogs,
+		},
+		{
+			name:            "Dry run is turned off",
+			existingObjects: []runtime.Object{runningPod, existingCM},
+			podLogs:         podLogs,
+		},
+		{
+			name:            "Changes are collected",
+			dryRun:          true,
+			existingObjects: []runtime.Object{runningPod},
+			podLogs:         podLogs,
+			expectedCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            testDryRunConfigMapName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				Data: map[string]string{
+					dryRunCreatesKey:      "app.test.com A",
+					dryRunUpdatesKey:      "api.test.com CNAME",
+					dryRunDeletesKey:      "old.test.com A",
+					dryRunUnrecognizedKey: "",
+				},
+			},
+		},
+		{
+			name:            "Changes are updated",
+			dryRun:          true,
+			existingObjects: []runtime.Object{runningPod, existingCM},
+			podLogs:         podLogs,
+			expectedCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            testDryRunConfigMapName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				Data: map[string]string{
+					dryRunCreatesKey:      "app.test.com A",
+					dryRunUpdatesKey:      "api.test.com CNAME",
+					dryRunDeletesKey:      "old.test.com A",
+					dryRunUnrecognizedKey: "",
+				},
+			},
+		},
+		{
+			name:            "Changes are not recognized",
+			dryRun:          true,
+			existingObjects: []runtime.Object{runningPod},
+			podLogs: fakePodLogsReader{
+				testDryRunPodName + "/external-dns-zone1": podLogs[testDryRunPodName+"/external-dns-zone1"],
+				testDryRunPodName + "/external-dns-zone2": `time="2024-05-14T10:00:00Z" level=info msg="Instantiating new Kubernetes client"`,
+			},
+			expectedCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            testDryRunConfigMapName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				Data: map[string]string{
+					dryRunCreatesKey:      "app.test.com A",
+					dryRunUpdatesKey:      "",
+					dryRunDeletesKey:      "old.test.com A",
+					dryRunUnrecognizedKey: "external-dns-zone2",
+				},
+			},
+			expectedEvent: true,
+		},
+		{
+			name:            "Containers are not started",
+			dryRun:          true,
+			existingObjects: []runtime.Object{runningPod, existingCM},
+			podLogs:         fakePodLogsReader{},
+			expectedCM:      existingCM,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithRuntimeObjects(tc.existingObjects...).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &reconciler{
+				config: Config{
+					Namespace:         test.OperandNamespace,
+					OperatorNamespace: test.OperatorNamespace,
+				},
+				client:   cl,
+				scheme:   test.Scheme,
+				log:      zap.New(zap.UseDevMode(true)),
+				podLogs:  tc.podLogs,
+				recorder: recorder,
+			}
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			extDNS.Spec.DryRun = tc.dryRun
+
+			if err := r.ensureExternalDNSDryRunConfigMap(context.TODO(), extDNS, []*appsv1.Deployment{deployment}); err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+			if gotEvent := len(recorder.Events) != 0; gotEvent != tc.expectedEvent {
+				t.Errorf("expected the unrecognized plan event %t, got %t", tc.expectedEvent, gotEvent)
+			}
+
+			gotCM := &corev1.ConfigMap{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Namespace: test.OperandNamespace, Name: testDryRunConfigMapName}, gotCM)
+			if tc.expectedCM == nil {
+				if !errors.IsNotFound(err) {
+					t.Fatalf("expected dry run configmap to be absent, got error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get dry run configmap: %v", err)
+			}
+			diffOpts := cmpopts.IgnoreFields(corev1.ConfigMap{}, "ResourceVersion", "Kind", "APIVersion")
+			if diff := cmp.Diff(*tc.expectedCM, *gotCM, diffOpts); diff != "" {
+				t.Errorf("unexpected configmap (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestComputeDryRunStatus(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDryRunConfigMapName,
+			Namespace: test.OperandNamespace,
+		},
+		Data: map[string]string{
+			dryRunCreatesKey: "app.test.com A\napp.test.com TXT",
+			dryRunUpdatesKey: "",
+			dryRunDeletesKey: "old.test.com A",
+		},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cm).Build()
+	r := &reconciler{
+		config: Config{
+			Namespace: test.OperandNamespace,
+		},
+		client: cl,
+		scheme: test.Scheme,
+		log:    zap.New(zap.UseDevMode(true)),
+	}
+	extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+
+	if got := r.computeDryRunStatus(context.TODO(), extDNS); got != nil {
+		t.Errorf("expected no dry run status when dry run is off, got %+v", got)
+	}
+
+	extDNS.Spec.DryRun = true
+	expected := &operatorv1beta1.ExternalDNSDryRunStatus{
+		ConfigMapName: testDryRunConfigMapName,
+		Creates:       2,
+		Updates:       0,
+		Deletes:       1,
+	}
+	if diff := cmp.Diff(expected, r.computeDryRunStatus(context.TODO(), extDNS)); diff != "" {
+		t.Errorf("unexpected dry run status (-want +got):\n%s", diff)
+	}
+
+	cm.Data[dryRunUnrecognizedKey] = "external-dns-zone1\nexternal-dns-zone2"
+	if err := cl.Update(context.TODO(), cm); err != nil {
+		t.Fatalf("failed to update dry run configmap: %v", err)
+	}
+	expected.Message = "The planned changes of containers external-dns-zone1, external-dns-zone2 could not be recognized in their logs, the counts may be incomplete."
+	if diff := cmp.Diff(expected, r.computeDryRunStatus(context.TODO(), extDNS)); diff != "" {
+		t.Errorf("unexpected dry run status with unrecognized plans (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	operatorutils "github.com/openshift/external-dns-operator/pkg/utils"
+)
+
+// ensureExternalDNSInfobloxGridCAConfigMap ensures that the Infoblox grid CA configmap
+// referenced by the given externalDNS is copied from the operator namespace into the operand namespace.
+// Returns a Boolean value indicating whether the source configmap exists, a pointer to the destination configmap, and an error when relevant.
+func (r *reconciler) ensureExternalDNSInfobloxGridCAConfigMap(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) (bool, *corev1.ConfigMap, error) {
+	sourceName := types.NamespacedName{
+		Namespace: r.config.OperatorNamespace,
+		Name:      controller.ExternalDNSInfobloxCAConfigMapNameFromProvider(externalDNS),
+	}
+	destName := controller.ExternalDNSDestInfobloxCAConfigMapName(r.config.Namespace, externalDNS.Name)
+
+	sourceExists, source, err := r.currentExternalDNSConfigMap(ctx, sourceName)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get the source Infoblox grid CA configmap %s: %w", sourceName, err)
+	} else if !sourceExists {
+		return false, nil, nil
+	}
+
+	desired := desiredExternalDNSInfobloxGridCAConfigMap(source, destName)
+	operatorutils.ApplyOperandMetadata(externalDNS, &desired.ObjectMeta)
+
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return false, nil, fmt.Errorf("failed to set the controller reference for Infoblox grid CA configmap: %w", err)
+	}
+
+	exist, current, err := r.currentExternalDNSConfigMap(ctx, destName)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get the target Infoblox grid CA configmap %s: %w", destName, err)
+	}
+
+	if !exist {
+		if err := r.createExternalDNSConfigMap(ctx, desired); err != nil {
+			return false, nil, err
+		}
+		_, current, err := r.currentExternalDNSConfigMap(ctx, destName)
+		return true, current, err
+	}
+
+	if updated, err := r.updateExternalDNSConfigMap(ctx, current, desired); err != nil {
+		return true, current, err
+	} else if updated {
+		_, current, err := r.currentExternalDNSConfigMap(ctx, destName)
+		return true, current, err
+	}
+
+	return true, current, nil
+}
+
+// deleteExternalDNSInfobloxGridCAConfigMap deletes the copy of the Infoblox grid CA configmap
+// from the operand namespace once the grid CA is no longer referenced by the given externalDNS.
+func (r *reconciler) deleteExternalDNSInfobloxGridCAConfigMap(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) error {
+	nsName := controller.ExternalDNSDestInfobloxCAConfigMapName(r.config.Namespace, externalDNS.Name)
+
+	exists, current, err := r.currentExternalDNSConfigMap(ctx, nsName)
+	if err != nil {
+		return fmt.Errorf("failed to get the target Infoblox grid CA configmap %s: %w", nsName, err)
+	} else if !exists {
+		return nil
+	}
+
+	// the configmap is no longer a dependency of the record cleanup
+	if controllerutil.ContainsFinalizer(current, recordCleanupFinalizer) {
+		patch := client.MergeFrom(current.DeepCopy())
+		controllerutil.RemoveFinalizer(current, recordCleanupFinalizer)
+		if err := r.client.Patch(ctx, current, patch); err != nil {
+			return fmt.Errorf("failed to remove the record cleanup finalizer from the target Infoblox grid CA configmap %s: %w", nsName, err)
+		}
+	}
+	if err := r.client.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the target Infoblox grid CA configmap %s: %w", nsName, err)
+	}
+	r.log.Info("deleted externalDNS configmap", "namespace", nsName.Namespace, "name", nsName.Name)
+	return nil
+}
+
+// currentExternalDNSConfigMap gets the configmap resource.
+func (r *reconciler) currentExternalDNSConfigMap(ctx context.Context, nsName types.NamespacedName) (bool, *corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, nsName, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, cm, nil
+}
+
+// desiredExternalDNSInfobloxGridCAConfigMap returns the desired destination configmap with the data of the source one.
+func desiredExternalDNSInfobloxGridCAConfigMap(source *corev1.ConfigMap, destName types.NamespacedName) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: destName.Namespace,
+			Name:      destName.Name,
+		},
+		Data: source.Data,
+	}
+}
+
+// createExternalDNSConfigMap creates the given configmap using the reconciler's client.
+func (r *reconciler) createExternalDNSConfigMap(ctx context.Context, cm *corev1.ConfigMap) error {
+	if err := r.client.Create(ctx, cm); err != nil {
+		return fmt.Errorf("failed to create externalDNS configmap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+
+	r.log.Info("created externalDNS configmap", "namespace", cm.Namespace, "name", cm.Name)
+	return nil
+}
+
+// updateExternalDNSConfigMap updates the data and the operand metadata of the current configmap if they differ from the desired ones.
+// Returns a boolean if an update was made, and an error when relevant.
+func (r *reconciler) updateExternalDNSConfigMap(ctx context.Context, current, desired *corev1.ConfigMap) (bool, error) {
+	updated := current.DeepCopy()
+	metadataChanged := operatorutils.OperandMetadataChanged(&current.ObjectMeta, &desired.ObjectMeta, &updated.ObjectMeta)
+	if !metadataChanged && reflect.DeepEqual(current.Data, desired.Data) {
+		return false, nil
+	}
+
+	updated.Data = desired.Data
+	if err := r.client.Update(ctx, updated); err != nil {
+		return false, fmt.Errorf("failed to update externalDNS configmap %s/%s: %w", updated.Namespace, updated.Name, err)
+	}
+
+	r.log.Info("updated externalDNS configmap", "namespace", updated.Namespace, "name", updated.Name)
+	return true, nil
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestEnsureExternalDNSInfobloxGridCAConfigMap(t *testing.T) {
+	extDNS := testInfobloxExternalDNSWithOptions(operatorv1beta1.SourceTypeService)
+	ownerRefs := []metav1.OwnerReference{
+		{
+			APIVersion:         operatorv1beta1.GroupVersion.String(),
+			Kind:               "ExternalDNS",
+			Name:               extDNS.Name,
+			Controller:         &test.TrueVar,
+			BlockOwnerDeletion: &test.TrueVar,
+		},
+	}
+	sourceCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "infoblox-grid-ca",
+			Namespace: test.OperatorNamespace,
+		},
+		Data: map[string]string{
+			"ca-bundle.crt": "new-bundle",
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		operandMetadata *operatorv1beta1.ExternalDNSOperandMetadata
+		existingObjects []runtime.Object
+		expectedExist   bool
+		expectedCM      *corev1.ConfigMap
+	}{
+		{
+			name:            "Source doesn't exist",
+			existingObjects: []runtime.Object{},
+			expectedExist:   false,
+		},
+		{
+			name:            "Destination doesn't exist",
+			existingObjects: []runtime.Object{sourceCM},
+			expectedExist:   true,
+			expectedCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            infobloxGridCAConfigMapName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				Data: map[string]string{
+					"ca-bundle.crt": "new-bundle",
+				},
+			},
+		},
+		{
+			name: "Destination is outdated",
+			existingObjects: []runtime.Object{
+				sourceCM,
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            infobloxGridCAConfigMapName,
+						Namespace:       test.OperandNamespace,
+						OwnerReferences: ownerRefs,
+					},
+					Data: map[string]string{
+						"ca-bundle.crt": "old-bundle",
+					},
+				},
+			},
+			expectedExist: true,
+			expectedCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            infobloxGridCAConfigMapName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				Data: map[string]string{
+					"ca-bundle.crt": "new-bundle",
+				},
+			},
+		},
+		{
+			name: "Destination misses the custom operand metadata",
+			operandMetadata: &operatorv1beta1.ExternalDNSOperandMetadata{
+				Labels: map[string]string{"cost-center": "dns"},
+			},
+			existingObjects: []runtime.Object{
+				sourceCM,
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            infobloxGridCAConfigMapName,
+						Namespace:       test.OperandNamespace,
+						OwnerReferences: ownerRefs,
+					},
+					Data: map[string]string{
+						"ca-bundle.crt": "new-bundle",
+					},
+				},
+			},
+			expectedExist: true,
+			expectedCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            infobloxGridCAConfigMapName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+					Labels:          map[string]string{"cost-center": "dns"},
+					Annotations: map[string]string{
+						"externaldns.olm.openshift.io/operand-labels": "cost-center",
+					},
+				},
+				Data: map[string]string{
+					"ca-bundle.crt": "new-bundle",
+				},
+			},
+		},
+		{
+			name: "Destination has the custom operand metadata removed from the spec",
+			existingObjects: []runtime.Object{
+				sourceCM,
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            infobloxGridCAConfigMapName,
+						Namespace:       test.OperandNamespace,
+						OwnerReferences: ownerRefs,
+						Labels:          map[string]string{"cost-center": "dns"},
+						Annotations: map[string]string{
+							"externaldns.olm.openshift.io/operand-labels": "cost-center",
+						},
+					},
+					Data: map[string]string{
+						"ca-bundle.crt": "new-bundle",
+					},
+				},
+			},
+			expectedExist: true,
+			expectedCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            infobloxGridCAConfigMapName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				Data: map[string]string{
+					"ca-bundle.crt": "new-bundle",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithRuntimeObjects(tc.existingObjects...).Build()
+			r := &reconciler{
+				config: Config{
+					Namespace:         test.OperandNamespace,
+					OperatorNamespace: test.OperatorNamespace,
+				},
+				client: cl,
+				scheme: test.Scheme,
+				log:    zap.New(zap.UseDevMode(true)),
+			}
+			extDNS := extDNS.DeepCopy()
+			extDNS.Spec.OperandMetadata = tc.operandMetadata
+			gotExist, gotCM, err := r.ensureExternalDNSInfobloxGridCAConfigMap(context.TODO(), extDNS)
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+			if gotExist != tc.expectedExist {
+				t.Errorf("expected source configmap's exist to be %t, got %t", tc.expectedExist, gotExist)
+			}
+			if tc.expectedCM == nil {
+				return
+			}
+			diffOpts := cmpopts.IgnoreFields(corev1.ConfigMap{}, "ResourceVersion", "Kind", "APIVersion")
+			if diff := cmp.Diff(*tc.expectedCM, *gotCM, diffOpts); diff != "" {
+				t.Errorf("unexpected configmap (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeleteExternalDNSInfobloxGridCAConfigMap(t *testing.T) {
+	extDNS := testInfobloxExternalDNS(operatorv1beta1.SourceTypeService)
+	destCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      infobloxGridCAConfigMapName,
+			Namespace: test.OperandNamespace,
+		},
+		Data: map[string]string{
+			"ca-bundle.crt": "old-bundle",
+		},
+	}
+
+	protectedDestCM := destCM.DeepCopy()
+	protectedDestCM.Finalizers = []string{recordCleanupFinalizer}
+
+	testCases := []struct {
+		name            string
+		existingObjects []runtime.Object
+	}{
+		{
+			name: "Destination doesn't exist",
+		},
+		{
+			name:            "Destination is deleted",
+			existingObjects: []runtime.Object{destCM},
+		},
+		{
+			name:            "Destination protected for the record cleanup is deleted",
+			existingObjects: []runtime.Object{protectedDestCM},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithRuntimeObjects(tc.existingObjects...).Build()
+			r := &reconciler{
+				config: Config{
+					Namespace:         test.OperandNamespace,
+					OperatorNamespace: test.OperatorNamespace,
+				},
+				client: cl,
+				scheme: test.Scheme,
+				log:    zap.New(zap.UseDevMode(true)),
+			}
+			if err := r.deleteExternalDNSInfobloxGridCAConfigMap(context.TODO(), extDNS); err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+			err := cl.Get(context.TODO(), types.NamespacedName{Namespace: test.OperandNamespace, Name: infobloxGridCAConfigMapName}, &corev1.ConfigMap{})
+			if !errors.IsNotFound(err) {
+				t.Errorf("expected the destination configmap to be deleted, got error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+)
+
+const (
+	debugZoneExpiredEventReason = "DebugZoneExpired"
+)
+
+// operandLogLevels maps the log levels of the API to the values of ExternalDNS "--log-level" flag.
+var operandLogLevels = map[operatorv1beta1.ExternalDNSLogLevel]string{
+	operatorv1beta1.LogLevelDebug:   "debug",
+	operatorv1beta1.LogLevelInfo:    "info",
+	operatorv1beta1.LogLevelWarning: "warning",
+	operatorv1beta1.LogLevelError:   "error",
+}
+
+// debugZone returns the zone switched to the debug log level by the annotations of the given externalDNS
+// and the time at which the switch expires.
+// Returns false if the annotations are not set or the expiry time is malformed.
+func debugZone(externalDNS *operatorv1beta1.ExternalDNS) (string, time.Time, bool) {
+	zone, found := externalDNS.Annotations[operatorv1beta1.DebugZoneAnnotation]
+	if !found {
+		return "", time.Time{}, false
+	}
+	expiry, err := time.Parse(time.RFC3339, externalDNS.Annotations[operatorv1beta1.DebugZoneExpiryAnnotation])
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return zone, expiry, true
+}
+
+// operandLogLevel returns the log level of the containers of the given zone.
+// The level of the spec is overridden by the debug level until the debug zone expires.
+func operandLogLevel(externalDNS *operatorv1beta1.ExternalDNS, zone string) string {
+	if debug, expiry, ok := debugZone(externalDNS); ok && zone != "" && debug == zone && clock.Now().Before(expiry) {
+		return operandLogLevels[operatorv1beta1.LogLevelDebug]
+	}
+	if logging := externalDNS.Spec.Logging; logging != nil {
+		if level, found := operandLogLevels[logging.Level]; found {
+			return level
+		}
+	}
+	return operandLogLevels[operatorv1beta1.LogLevelDebug]
+}
+
+// ensureExternalDNSDebugZone removes the expired debug zone annotations from the given externalDNS.
+// Returns the time left until the debug zone expires, zero if no zone is debugged, and an error when relevant.
+func (r *reconciler) ensureExternalDNSDebugZone(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) (time.Duration, error) {
+	_, debugged := externalDNS.Annotations[operatorv1beta1.DebugZoneAnnotation]
+	_, expiring := externalDNS.Annotations[operatorv1beta1.DebugZoneExpiryAnnotation]
+	if !debugged && !expiring {
+		return 0, nil
+	}
+
+	if _, expiry, ok := debugZone(externalDNS); ok {
+		if left := expiry.Sub(clock.Now()); left > 0 {
+			return left, nil
+		}
+	}
+
+	zone := externalDNS.Annotations[operatorv1beta1.DebugZoneAnnotation]
+
+	patch := client.MergeFrom(externalDNS.DeepCopy())
+	delete(externalDNS.Annotations, operatorv1beta1.DebugZoneAnnotation)
+	delete(externalDNS.Annotations, operatorv1beta1.DebugZoneExpiryAnnotation)
+	if err := r.client.Patch(ctx, externalDNS, patch); err != nil {
+		return 0, fmt.Errorf("failed to remove the debug zone annotations from externalDNS %s: %w", externalDNS.Name, err)
+	}
+	r.recorder.Eventf(externalDNS, corev1.EventTypeNormal, debugZoneExpiredEventReason, "The debug log level of zone %q expired, the log level of the spec is restored", zone)
+	r.log.Info("reverted the debug zone of externalDNS", "name", externalDNS.Name, "zone", zone)
+	return 0, nil
+}
+
+// requeueBeforeDebugZoneExpiry returns the given result requeued no later than the expiry of the debug zone.
+func requeueBeforeDebugZoneExpiry(result reconcile.Result, expiresIn time.Duration) reconcile.Result {
+	if expiresIn > 0 && (result.RequeueAfter == 0 || expiresIn < result.RequeueAfter) {
+		result.RequeueAfter = expiresIn
+	}
+	return result
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestOperandLogLevel(t *testing.T) {
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	testCases := []struct {
+		name          string
+		logging       *operatorv1beta1.ExternalDNSLogging
+		annotations   map[string]string
+		zone          string
+		expectedLevel string
+	}{
+		{
+			name:          "Logging is not set",
+			zone:          test.PublicZone,
+			expectedLevel: "debug",
+		},
+		{
+			name:          "Level from the spec",
+			logging:       &operatorv1beta1.ExternalDNSLogging{Level: operatorv1beta1.LogLevelWarning},
+			zone:          test.PublicZone,
+			expectedLevel: "warning",
+		},
+		{
+			name:    "Debugged zone",
+			logging: &operatorv1beta1.ExternalDNSLogging{Level: operatorv1beta1.LogLevelInfo},
+			annotations: map[string]string{
+				operatorv1beta1.DebugZoneAnnotation:       test.PublicZone,
+				operatorv1beta1.DebugZoneExpiryAnnotation: future,
+			},
+			zone:          test.PublicZone,
+			expectedLevel: "debug",
+		},
+		{
+			name:    "Other zone is debugged",
+			logging: &operatorv1beta1.ExternalDNSLogging{Level: operatorv1beta1.LogLevelInfo},
+			annotations: map[string]string{
+				operatorv1beta1.DebugZoneAnnotation:       test.PrivateZone,
+				operatorv1beta1.DebugZoneExpiryAnnotation: future,
+			},
+			zone:          test.PublicZone,
+			expectedLevel: "info",
+		},
+		{
+			name:    "Debug zone expired",
+			logging: &operatorv1beta1.ExternalDNSLogging{Level: operatorv1beta1.LogLevelInfo},
+			annotations: map[string]string{
+				operatorv1beta1.DebugZoneAnnotation:       test.PublicZone,
+				operatorv1beta1.DebugZoneExpiryAnnotation: past,
+			},
+			zone:          test.PublicZone,
+			expectedLevel: "info",
+		},
+		{
+			name:    "Malformed debug zone expiry",
+			logging: &operatorv1beta1.ExternalDNSLogging{Level: operatorv1beta1.LogLevelError},
+			annotations: map[string]string{
+				operatorv1beta1.DebugZoneAnnotation:       test.PublicZone,
+				operatorv1beta1.DebugZoneExpiryAnnotation: "1h",
+			},
+			zone:          test.PublicZone,
+			expectedLevel: "error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			extDNS.Annotations = tc.annotations
+			extDNS.Spec.Logging = tc.logging
+
+			if got := operandLogLevel(extDNS, tc.zone); got != tc.expectedLevel {
+				t.Errorf("expected log level %q, got %q", tc.expectedLevel, got)
+			}
+		})
+	}
+}
+
+func TestEnsureExternalDNSDebugZone(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		annotations           map[string]string
+		expectedExpiresIn     bool
+		expectedRemoved       bool
+		expectedEventsReasons []string
+	}{
+		{
+			name: "No debug zone",
+		},
+		{
+			name: "Debug zone is active",
+			annotations: map[string]string{
+				operatorv1beta1.DebugZoneAnnotation:       test.PublicZone,
+				operatorv1beta1.DebugZoneExpiryAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+			},
+			expectedExpiresIn: true,
+		},
+		{
+			name: "Debug zone expired",
+			annotations: map[string]string{
+				operatorv1beta1.DebugZoneAnnotation:       test.PublicZone,
+				operatorv1beta1.DebugZoneExpiryAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339),
+			},
+			expectedRemoved:       true,
+			expectedEventsReasons: []string{debugZoneExpiredEventReason},
+		},
+		{
+			name: "Debug zone without expiry",
+			annotations: map[string]string{
+				operatorv1beta1.DebugZoneAnnotation: test.PublicZone,
+			},
+			expectedRemoved:       true,
+			expectedEventsReasons: []string{debugZoneExpiredEventReason},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			extDNS.Annotations = tc.annotations
+
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(extDNS).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &reconciler{
+				client:   cl,
+				scheme:   test.Scheme,
+				log:      zap.New(zap.UseDevMode(true)),
+				recorder: recorder,
+			}
+
+			gotExpiresIn, err := r.ensureExternalDNSDebugZone(context.TODO(), extDNS)
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+			if tc.expectedExpiresIn != (gotExpiresIn > 0) {
+				t.Errorf("unexpected time left until the debug zone expires: %s", gotExpiresIn)
+			}
+
+			gotExtDNS := &operatorv1beta1.ExternalDNS{}
+			if err := cl.Get(context.TODO(), types.NamespacedName{Name: test.Name}, gotExtDNS); err != nil {
+				t.Fatalf("failed to get externalDNS: %v", err)
+			}
+			_, found := gotExtDNS.Annotations[operatorv1beta1.DebugZoneAnnotation]
+			if tc.expectedRemoved && found {
+				t.Error("expected the debug zone annotation to be removed")
+			}
+			if len(tc.annotations) > 0 && !tc.expectedRemoved && !found {
+				t.Error("expected the debug zone annotation to be kept")
+			}
+
+			close(recorder.Events)
+			gotReasons := []string{}
+			for event := range recorder.Events {
+				// the fake recorder formats the events as "<type> <reason> <message>"
+				gotReasons = append(gotReasons, strings.Fields(event)[1])
+			}
+			if diff := cmp.Diff(tc.expectedEventsReasons, gotReasons, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected events (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
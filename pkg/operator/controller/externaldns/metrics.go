@@ -0,0 +1,375 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+)
+
+const (
+	metricsServiceSuffix = "-metrics"
+	metricsPath          = "/metrics"
+	// containerTargetLabel is the Prometheus label set to the name of the scraped container
+	containerTargetLabel = "container"
+	// servingCertSecretAnnotation requests a serving certificate from the OpenShift service CA
+	servingCertSecretAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+	// the token of Prometheus authenticates it to the metrics proxies
+	prometheusBearerTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// the bundle of the OpenShift service CA mounted in the Prometheus pods of the cluster monitoring
+	prometheusServingCertsCAFile = "/etc/prometheus/configmaps/serving-certs-ca-bundle/service-ca.crt"
+)
+
+// serviceMonitorGVK is the kind of the Prometheus operator's ServiceMonitor.
+// The unstructured objects are used to avoid the dependency on the Prometheus operator's API.
+var serviceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+
+// ensureExternalDNSMetrics ensures that a metrics service and, if requested, a service monitor
+// exist for each of the given deployments of the given externalDNS.
+// The services and the service monitors of the removed deployments are deleted,
+// all of them are deleted once the metrics exposure is disabled.
+func (r *reconciler) ensureExternalDNSMetrics(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, deployments []*appsv1.Deployment) error {
+	desiredServices, desiredMonitors := map[string]bool{}, map[string]bool{}
+	if metrics := externalDNS.Spec.Metrics; metrics != nil {
+		for _, deployment := range deployments {
+			service := desiredMetricsService(externalDNS, deployment, r.config.IsOpenShift)
+			if err := controllerutil.SetControllerReference(externalDNS, service, r.scheme); err != nil {
+				return fmt.Errorf("failed to set the controller reference for metrics service: %w", err)
+			}
+			if err := r.ensureMetricsService(ctx, service); err != nil {
+				return err
+			}
+			desiredServices[service.Name] = true
+
+			if !metrics.ServiceMonitor {
+				continue
+			}
+			monitor, err := desiredServiceMonitor(externalDNS, service, deployment, r.config.IsOpenShift)
+			if err != nil {
+				return err
+			}
+			if err := controllerutil.SetControllerReference(externalDNS, monitor, r.scheme); err != nil {
+				return fmt.Errorf("failed to set the controller reference for service monitor: %w", err)
+			}
+			if err := r.ensureServiceMonitor(ctx, monitor); err != nil {
+				return err
+			}
+			desiredMonitors[monitor.GetName()] = true
+		}
+	}
+
+	if err := r.deleteStaleMetricsServices(ctx, externalDNS, desiredServices); err != nil {
+		return err
+	}
+	return r.deleteStaleServiceMonitors(ctx, externalDNS, desiredMonitors)
+}
+
+// desiredMetricsService returns the service exposing the secure ports of the metrics proxies of the given deployment.
+// The metrics ports of the containers stay on the loopback of the pods.
+// On OpenShift, the service CA issues the serving certificate of the proxies.
+func desiredMetricsService(externalDNS *operatorv1beta1.ExternalDNS, deployment *appsv1.Deployment, isOpenShift bool) *corev1.Service {
+	labels := operandLabels(externalDNS)
+	if container, found := deployment.Labels[operandContainerLabel]; found {
+		labels[operandContainerLabel] = container
+	}
+
+	ports := []corev1.ServicePort{}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if !isMetricsProxyContainer(container) {
+			continue
+		}
+		for _, port := range container.Ports {
+			ports = append(ports, corev1.ServicePort{
+				Name:       port.Name,
+				Protocol:   corev1.ProtocolTCP,
+				Port:       port.ContainerPort,
+				TargetPort: intstr.FromString(port.Name),
+			})
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Port < ports[j].Port
+	})
+
+	var selector map[string]string
+	if deployment.Spec.Selector != nil {
+		selector = deployment.Spec.Selector.MatchLabels
+	}
+
+	var annotations map[string]string
+	if isOpenShift {
+		annotations = map[string]string{
+			servingCertSecretAnnotation: metricsServiceName(deployment),
+		}
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        metricsServiceName(deployment),
+			Namespace:   deployment.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+}
+
+// metricsServiceName returns the name of the metrics service of the given deployment,
+// also used for the secret of its serving certificate.
+func metricsServiceName(deployment *appsv1.Deployment) string {
+	return deployment.Name + metricsServiceSuffix
+}
+
+// desiredServiceMonitor returns the service monitor scraping the ports of the given metrics service.
+// The series of each port are labelled with the name of the container whose metrics are proxied by it.
+// Prometheus authenticates with its service account token, the serving certificate of the proxies
+// is verified with the OpenShift service CA, the self-signed one is trusted on other platforms.
+func desiredServiceMonitor(externalDNS *operatorv1beta1.ExternalDNS, service *corev1.Service, deployment *appsv1.Deployment, isOpenShift bool) (*unstructured.Unstructured, error) {
+	portContainers := map[int32]string{}
+	for _, container := range operandContainers(deployment.Spec.Template.Spec.Containers) {
+		if port, found := containerMetricsPort(container); found {
+			portContainers[metricsProxyPort(port)] = container.Name
+		}
+	}
+
+	tlsConfig := map[string]interface{}{
+		"insecureSkipVerify": true,
+	}
+	if isOpenShift {
+		tlsConfig = map[string]interface{}{
+			"caFile":     prometheusServingCertsCAFile,
+			"serverName": fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace),
+		}
+	}
+
+	endpoints := []interface{}{}
+	for _, port := range service.Spec.Ports {
+		endpoint := map[string]interface{}{
+			"port":            port.Name,
+			"path":            metricsPath,
+			"scheme":          "https",
+			"bearerTokenFile": prometheusBearerTokenFile,
+			"tlsConfig":       tlsConfig,
+			"relabelings": []interface{}{
+				map[string]interface{}{
+					"action":      "replace",
+					"targetLabel": containerTargetLabel,
+					"replacement": portContainers[port.Port],
+				},
+			},
+		}
+		if interval := externalDNS.Spec.Metrics.ScrapeInterval; interval != nil {
+			endpoint["interval"] = interval.Duration.String()
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	matchLabels := map[string]interface{}{}
+	for key, value := range service.Labels {
+		matchLabels[key] = value
+	}
+
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(serviceMonitorGVK)
+	monitor.SetName(service.Name)
+	monitor.SetNamespace(service.Namespace)
+	monitor.SetLabels(service.Labels)
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+		"endpoints":    endpoints,
+		"targetLabels": []interface{}{appInstanceLabel},
+	}
+	if err := unstructured.SetNestedField(monitor.Object, spec, "spec"); err != nil {
+		return nil, fmt.Errorf("failed to build service monitor %s/%s: %w", service.Namespace, service.Name, err)
+	}
+	return monitor, nil
+}
+
+// containerMetricsPort returns the metrics port of the given operand container.
+// Returns false if the container doesn't expose the metrics.
+func containerMetricsPort(container corev1.Container) (int32, bool) {
+	for _, arg := range container.Args {
+		if !strings.HasPrefix(arg, metricsAddressArg) {
+			continue
+		}
+		_, portStr, err := net.SplitHostPort(strings.TrimPrefix(arg, metricsAddressArg))
+		if err != nil {
+			return 0, false
+		}
+		port, err := strconv.ParseInt(portStr, 10, 32)
+		if err != nil {
+			return 0, false
+		}
+		return int32(port), true
+	}
+	return 0, false
+}
+
+// ensureMetricsService creates the given metrics service or updates it if it differs from the current one.
+func (r *reconciler) ensureMetricsService(ctx context.Context, desired *corev1.Service) error {
+	nsName := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+
+	current := &corev1.Service{}
+	if err := r.client.Get(ctx, nsName, current); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get metrics service %s: %w", nsName, err)
+		}
+		if err := r.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create metrics service %s: %w", nsName, err)
+		}
+		r.log.Info("created metrics service", "namespace", nsName.Namespace, "name", nsName.Name)
+		return nil
+	}
+
+	annotationsChanged := false
+	for key, value := range desired.Annotations {
+		if current.Annotations[key] != value {
+			annotationsChanged = true
+			break
+		}
+	}
+	if !annotationsChanged &&
+		equality.Semantic.DeepEqual(current.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(current.OwnerReferences, desired.OwnerReferences) &&
+		equality.Semantic.DeepEqual(current.Spec.Selector, desired.Spec.Selector) &&
+		equality.Semantic.DeepEqual(current.Spec.Ports, desired.Spec.Ports) {
+		return nil
+	}
+
+	// keep the fields allocated by the API, e.g. the cluster IP,
+	// and the annotations set by the others, e.g. the service CA
+	updated := current.DeepCopy()
+	if len(desired.Annotations) > 0 && updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	for key, value := range desired.Annotations {
+		updated.Annotations[key] = value
+	}
+	updated.Labels = desired.Labels
+	updated.OwnerReferences = desired.OwnerReferences
+	updated.Spec.Selector = desired.Spec.Selector
+	updated.Spec.Ports = desired.Spec.Ports
+	if err := r.client.Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update metrics service %s: %w", nsName, err)
+	}
+	r.log.Info("updated metrics service", "namespace", nsName.Namespace, "name", nsName.Name)
+	return nil
+}
+
+// ensureServiceMonitor creates the given service monitor or updates it if it differs from the current one.
+func (r *reconciler) ensureServiceMonitor(ctx context.Context, desired *unstructured.Unstructured) error {
+	nsName := types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(serviceMonitorGVK)
+	if err := r.client.Get(ctx, nsName, current); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get service monitor %s: %w", nsName, err)
+		}
+		if err := r.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create service monitor %s: %w", nsName, err)
+		}
+		r.log.Info("created service monitor", "namespace", nsName.Namespace, "name", nsName.Name)
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(current.GetLabels(), desired.GetLabels()) &&
+		equality.Semantic.DeepEqual(current.GetOwnerReferences(), desired.GetOwnerReferences()) &&
+		equality.Semantic.DeepEqual(current.Object["spec"], desired.Object["spec"]) {
+		return nil
+	}
+
+	updated := current.DeepCopy()
+	updated.SetLabels(desired.GetLabels())
+	updated.SetOwnerReferences(desired.GetOwnerReferences())
+	updated.Object["spec"] = desired.Object["spec"]
+	if err := r.client.Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update service monitor %s: %w", nsName, err)
+	}
+	r.log.Info("updated service monitor", "namespace", nsName.Namespace, "name", nsName.Name)
+	return nil
+}
+
+// deleteStaleMetricsServices deletes the metrics services controlled by the given externalDNS
+// whose names are not in the given set of the desired ones.
+func (r *reconciler) deleteStaleMetricsServices(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, desiredNames map[string]bool) error {
+	services := &corev1.ServiceList{}
+	if err := r.client.List(ctx, services, client.InNamespace(r.config.Namespace), client.MatchingLabels(operandLabels(externalDNS))); err != nil {
+		return fmt.Errorf("failed to list metrics services: %w", err)
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		if desiredNames[service.Name] || !metav1.IsControlledBy(service, externalDNS) {
+			continue
+		}
+		if err := r.client.Delete(ctx, service); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete stale metrics service %s/%s: %w", service.Namespace, service.Name, err)
+		}
+		r.log.Info("deleted stale metrics service", "namespace", service.Namespace, "name", service.Name)
+	}
+	return nil
+}
+
+// deleteStaleServiceMonitors deletes the service monitors controlled by the given externalDNS
+// whose names are not in the given set of the desired ones.
+// Nothing is deleted if the ServiceMonitor API is not installed in the cluster.
+func (r *reconciler) deleteStaleServiceMonitors(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, desiredNames map[string]bool) error {
+	monitors := &unstructured.UnstructuredList{}
+	monitors.SetGroupVersionKind(serviceMonitorGVK.GroupVersion().WithKind(serviceMonitorGVK.Kind + "List"))
+	if err := r.client.List(ctx, monitors, client.InNamespace(r.config.Namespace), client.MatchingLabels(operandLabels(externalDNS))); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list service monitors: %w", err)
+	}
+	for i := range monitors.Items {
+		monitor := &monitors.Items[i]
+		if desiredNames[monitor.GetName()] || !metav1.IsControlledBy(monitor, externalDNS) {
+			continue
+		}
+		if err := r.client.Delete(ctx, monitor); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete stale service monitor %s/%s: %w", monitor.GetNamespace(), monitor.GetName(), err)
+		}
+		r.log.Info("deleted stale service monitor", "namespace", monitor.GetNamespace(), "name", monitor.GetName())
+	}
+	return nil
+}
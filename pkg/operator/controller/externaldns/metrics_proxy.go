@@ -0,0 +1,153 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// the metrics listener of ExternalDNS stays on the loopback of the pod,
+	// a kube-rbac-proxy sidecar serves it on the pod IP over TLS
+	metricsProxyContainerPrefix = "kube-rbac-proxy-"
+	metricsProxyPortNamePrefix  = "https-"
+	metricsProxyListenAddress   = "0.0.0.0"
+	// the secure port of the proxy is the metrics port shifted by the offset
+	metricsProxyPortOffset = 1000
+	metricsProxyCPURequest = "10m"
+	metricsProxyMemRequest = "20Mi"
+	// serving certificate issued by the OpenShift service CA for the metrics service
+	metricsServingCertVolumeName = "metrics-serving-cert"
+	metricsServingCertMountPath  = "/var/run/secrets/serving-cert"
+	metricsServingCertFile       = metricsServingCertMountPath + "/tls.crt"
+	metricsServingKeyFile        = metricsServingCertMountPath + "/tls.key"
+)
+
+// addMetricsProxyContainers adds a kube-rbac-proxy sidecar with the given image
+// for each ExternalDNS container of the given deployment.
+// The health endpoint is proxied without the authentication to let the kubelet probe it,
+// all the other paths, including the metrics, require a token authorized by the API.
+// The proxies serve the certificate from the given secret, a self-signed one if the name is empty.
+func addMetricsProxyContainers(depl *appsv1.Deployment, image, servingCertSecretName string) {
+	proxies := []corev1.Container{}
+	for _, container := range operandContainers(depl.Spec.Template.Spec.Containers) {
+		port, found := containerMetricsPort(container)
+		if !found {
+			continue
+		}
+		proxies = append(proxies, metricsProxyContainer(image, port, servingCertSecretName != ""))
+	}
+	depl.Spec.Template.Spec.Containers = append(depl.Spec.Template.Spec.Containers, proxies...)
+
+	if servingCertSecretName != "" {
+		depl.Spec.Template.Spec.Volumes = append(depl.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: metricsServingCertVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: servingCertSecretName,
+					// the volume is kept once the metrics are no longer exposed
+					// while the secret is removed along with the service
+					Optional: ptr.To[bool](true),
+				},
+			},
+		})
+	}
+}
+
+// metricsProxyContainer returns the kube-rbac-proxy container forwarding to the given metrics port.
+// The proxy serves the mounted serving certificate if requested.
+func metricsProxyContainer(image string, metricsPort int32, servingCert bool) corev1.Container {
+	proxyPort := metricsProxyPort(metricsPort)
+	args := []string{
+		fmt.Sprintf("--secure-listen-address=%s:%d", metricsProxyListenAddress, proxyPort),
+		fmt.Sprintf("--upstream=http://%s:%d/", defaultMetricsAddress, metricsPort),
+		"--ignore-paths=" + healthzPath,
+		"--logtostderr=true",
+		"--http2-disable",
+	}
+	var volumeMounts []corev1.VolumeMount
+	if servingCert {
+		args = append(args, "--tls-cert-file="+metricsServingCertFile, "--tls-private-key-file="+metricsServingKeyFile)
+		volumeMounts = []corev1.VolumeMount{
+			{
+				Name:      metricsServingCertVolumeName,
+				MountPath: metricsServingCertMountPath,
+				ReadOnly:  true,
+			},
+		}
+	}
+	return corev1.Container{
+		Name:         fmt.Sprintf("%s%d", metricsProxyContainerPrefix, metricsPort),
+		Image:        image,
+		Args:         args,
+		VolumeMounts: volumeMounts,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          fmt.Sprintf("%s%d", metricsProxyPortNamePrefix, proxyPort),
+				ContainerPort: proxyPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(metricsProxyCPURequest),
+				corev1.ResourceMemory: resource.MustParse(metricsProxyMemRequest),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{allCapabilities},
+			},
+			Privileged:               ptr.To[bool](false),
+			RunAsNonRoot:             ptr.To[bool](true),
+			AllowPrivilegeEscalation: ptr.To[bool](false),
+			ReadOnlyRootFilesystem:   ptr.To[bool](true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+	}
+}
+
+// metricsProxyPort returns the secure port of the proxy forwarding to the given metrics port.
+func metricsProxyPort(metricsPort int32) int32 {
+	return metricsPort + metricsProxyPortOffset
+}
+
+// isMetricsProxyContainer returns true if the given container is a kube-rbac-proxy sidecar.
+func isMetricsProxyContainer(container corev1.Container) bool {
+	return strings.HasPrefix(container.Name, metricsProxyContainerPrefix)
+}
+
+// operandContainers returns the ExternalDNS containers from the given ones, without the proxy sidecars.
+func operandContainers(containers []corev1.Container) []corev1.Container {
+	operands := []corev1.Container{}
+	for _, container := range containers {
+		if !isMetricsProxyContainer(container) {
+			operands = append(operands, container)
+		}
+	}
+	return operands
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestDesiredExternalDNSDeploymentsMetricsProxies(t *testing.T) {
+	servingCertProxy := func(port int32) corev1.Container {
+		proxy := testMetricsProxyContainer(port)
+		proxy.Args = append(proxy.Args,
+			"--tls-cert-file=/var/run/secrets/serving-cert/tls.crt",
+			"--tls-private-key-file=/var/run/secrets/serving-cert/tls.key",
+		)
+		proxy.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      "metrics-serving-cert",
+				MountPath: "/var/run/secrets/serving-cert",
+				ReadOnly:  true,
+			},
+		}
+		return proxy
+	}
+	servingCertVolume := corev1.Volume{
+		Name: "metrics-serving-cert",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: test.OperandName + "-metrics",
+				Optional:   ptr.To[bool](true),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                  string
+		metrics               *operatorv1beta1.ExternalDNSMetrics
+		isOpenShift           bool
+		expectedProxies       []corev1.Container
+		expectedServingVolume *corev1.Volume
+	}{
+		{
+			name:            "Self-signed certificate",
+			metrics:         &operatorv1beta1.ExternalDNSMetrics{},
+			expectedProxies: []corev1.Container{testMetricsProxyContainer(7979), testMetricsProxyContainer(7980)},
+		},
+		{
+			name:            "Metrics are not exposed on OpenShift",
+			isOpenShift:     true,
+			expectedProxies: []corev1.Container{testMetricsProxyContainer(7979), testMetricsProxyContainer(7980)},
+		},
+		{
+			name:                  "Serving certificate of the service CA",
+			metrics:               &operatorv1beta1.ExternalDNSMetrics{},
+			isOpenShift:           true,
+			expectedProxies:       []corev1.Container{servingCertProxy(7979), servingCertProxy(7980)},
+			expectedServingVolume: &servingCertVolume,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNSZones([]string{test.PublicZone, test.PrivateZone}, operatorv1beta1.SourceTypeService)
+			extDNS.Spec.Metrics = tc.metrics
+
+			depls, err := desiredExternalDNSDeployments(&deploymentConfig{
+				namespace:      test.OperandNamespace,
+				image:          test.OperandImage,
+				proxyImage:     test.ProxyImage,
+				serviceAccount: serviceAccount,
+				externalDNS:    extDNS,
+				isOpenShift:    tc.isOpenShift,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+			if len(depls) != 1 {
+				t.Fatalf("expected a single deployment, got %d", len(depls))
+			}
+
+			containers := depls[0].Spec.Template.Spec.Containers
+			gotProxies := []corev1.Container{}
+			for _, container := range containers {
+				if isMetricsProxyContainer(container) {
+					gotProxies = append(gotProxies, container)
+				}
+			}
+			contOpt := cmpopts.IgnoreFields(corev1.Container{}, "TerminationMessagePolicy", "ImagePullPolicy")
+			if diff := cmp.Diff(tc.expectedProxies, gotProxies, contOpt); diff != "" {
+				t.Errorf("unexpected metrics proxies (-want +got):\n%s", diff)
+			}
+
+			var gotServingVolume *corev1.Volume
+			for i, volume := range depls[0].Spec.Template.Spec.Volumes {
+				if volume.Name == "metrics-serving-cert" {
+					gotServingVolume = &depls[0].Spec.Template.Spec.Volumes[i]
+				}
+			}
+			if diff := cmp.Diff(tc.expectedServingVolume, gotServingVolume); diff != "" {
+				t.Errorf("unexpected serving certificate volume (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestEnsureExternalDNSMetrics(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      test.OperandName,
+			Namespace: test.OperandNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					appNameLabel:     ExternalDNSBaseName,
+					appInstanceLabel: test.Name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "external-dns-zone2",
+							Args: []string{"--metrics-address=127.0.0.1:7980", "--provider=aws"},
+						},
+						{
+							Name: "external-dns-zone1",
+							Args: []string{"--metrics-address=127.0.0.1:7979", "--provider=aws"},
+						},
+						testMetricsProxyContainer(7980),
+						testMetricsProxyContainer(7979),
+					},
+				},
+			},
+		},
+	}
+	serviceName := test.OperandName + "-metrics"
+	labels := map[string]string{
+		appNameLabel:     ExternalDNSBaseName,
+		appInstanceLabel: test.Name,
+	}
+	expectedService := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: test.OperandNamespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{Name: "https-8979", Protocol: corev1.ProtocolTCP, Port: 8979, TargetPort: intstr.FromString("https-8979")},
+				{Name: "https-8980", Protocol: corev1.ProtocolTCP, Port: 8980, TargetPort: intstr.FromString("https-8980")},
+			},
+		},
+	}
+	expectedOpenShiftService := *expectedService.DeepCopy()
+	expectedOpenShiftService.Annotations = map[string]string{
+		"service.beta.openshift.io/serving-cert-secret-name": serviceName,
+	}
+	expectedMonitorSpec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				appNameLabel:     ExternalDNSBaseName,
+				appInstanceLabel: test.Name,
+			},
+		},
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"port":            "https-8979",
+				"path":            "/metrics",
+				"scheme":          "https",
+				"bearerTokenFile": "/var/run/secrets/kubernetes.io/serviceaccount/token",
+				"tlsConfig":       map[string]interface{}{"insecureSkipVerify": true},
+				"interval":        "30s",
+				"relabelings": []interface{}{
+					map[string]interface{}{"action": "replace", "targetLabel": "container", "replacement": "external-dns-zone1"},
+				},
+			},
+			map[string]interface{}{
+				"port":            "https-8980",
+				"path":            "/metrics",
+				"scheme":          "https",
+				"bearerTokenFile": "/var/run/secrets/kubernetes.io/serviceaccount/token",
+				"tlsConfig":       map[string]interface{}{"insecureSkipVerify": true},
+				"interval":        "30s",
+				"relabelings": []interface{}{
+					map[string]interface{}{"action": "replace", "targetLabel": "container", "replacement": "external-dns-zone2"},
+				},
+			},
+		},
+		"targetLabels": []interface{}{appInstanceLabel},
+	}
+	expectedOpenShiftMonitorSpec := runtime.DeepCopyJSON(expectedMonitorSpec)
+	for _, endpoint := range expectedOpenShiftMonitorSpec["endpoints"].([]interface{}) {
+		endpoint.(map[string]interface{})["tlsConfig"] = map[string]interface{}{
+			"caFile":     "/etc/prometheus/configmaps/serving-certs-ca-bundle/service-ca.crt",
+			"serverName": serviceName + "." + test.OperandNamespace + ".svc",
+		}
+	}
+
+	testCases := []struct {
+		name                string
+		metrics             *operatorv1beta1.ExternalDNSMetrics
+		isOpenShift         bool
+		existingObjects     []runtime.Object
+		expectedServices    []corev1.Service
+		expectedMonitorSpec map[string]interface{}
+	}{
+		{
+			name: "Metrics are not exposed",
+		},
+		{
+			name:             "Metrics service is created",
+			metrics:          &operatorv1beta1.ExternalDNSMetrics{},
+			expectedServices: []corev1.Service{expectedService},
+		},
+		{
+			name:    "Metrics service is updated",
+			metrics: &operatorv1beta1.ExternalDNSMetrics{},
+			existingObjects: []runtime.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: test.OperandNamespace,
+						Labels:    labels,
+					},
+					Spec: corev1.ServiceSpec{
+						Type:      corev1.ServiceTypeClusterIP,
+						ClusterIP: "172.30.0.10",
+						Selector:  labels,
+						Ports: []corev1.ServicePort{
+							{Name: "metrics-7979", Protocol: corev1.ProtocolTCP, Port: 7979, TargetPort: intstr.FromInt32(7979)},
+						},
+					},
+				},
+			},
+			expectedServices: []corev1.Service{
+				func() corev1.Service {
+					svc := *expectedService.DeepCopy()
+					svc.Spec.ClusterIP = "172.30.0.10"
+					return svc
+				}(),
+			},
+		},
+		{
+			name: "Service monitor is created",
+			metrics: &operatorv1beta1.ExternalDNSMetrics{
+				ServiceMonitor: true,
+				ScrapeInterval: &metav1.Duration{Duration: 30 * time.Second},
+			},
+			expectedServices:    []corev1.Service{expectedService},
+			expectedMonitorSpec: expectedMonitorSpec,
+		},
+		{
+			name: "Service monitor verifies the service CA on OpenShift",
+			metrics: &operatorv1beta1.ExternalDNSMetrics{
+				ServiceMonitor: true,
+				ScrapeInterval: &metav1.Duration{Duration: 30 * time.Second},
+			},
+			isOpenShift:         true,
+			expectedServices:    []corev1.Service{expectedOpenShiftService},
+			expectedMonitorSpec: expectedOpenShiftMonitorSpec,
+		},
+		{
+			name:        "Annotations of the service CA are kept",
+			metrics:     &operatorv1beta1.ExternalDNSMetrics{},
+			isOpenShift: true,
+			existingObjects: []runtime.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceName,
+						Namespace: test.OperandNamespace,
+						Labels:    labels,
+						Annotations: map[string]string{
+							"service.beta.openshift.io/serving-cert-signed-by": "openshift-service-serving-signer",
+						},
+					},
+					Spec: expectedService.Spec,
+				},
+			},
+			expectedServices: []corev1.Service{
+				func() corev1.Service {
+					svc := *expectedOpenShiftService.DeepCopy()
+					svc.Annotations["service.beta.openshift.io/serving-cert-signed-by"] = "openshift-service-serving-signer"
+					return svc
+				}(),
+			},
+		},
+		{
+			name: "Stale metrics service and service monitor are deleted",
+			existingObjects: []runtime.Object{
+				testOwnedMetricsService(serviceName, labels),
+				testOwnedServiceMonitor(serviceName, labels),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			extDNS.Spec.Metrics = tc.metrics
+
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(append(tc.existingObjects, extDNS)...).Build()
+			r := &reconciler{
+				config: Config{
+					Namespace:   test.OperandNamespace,
+					IsOpenShift: tc.isOpenShift,
+				},
+				client: cl,
+				scheme: test.Scheme,
+				log:    zap.New(zap.UseDevMode(true)),
+			}
+
+			if err := r.ensureExternalDNSMetrics(context.TODO(), extDNS, []*appsv1.Deployment{deployment}); err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			services := &corev1.ServiceList{}
+			if err := cl.List(context.TODO(), services, client.InNamespace(test.OperandNamespace)); err != nil {
+				t.Fatalf("failed to list services: %v", err)
+			}
+			svcOpt := cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion", "OwnerReferences")
+			if diff := cmp.Diff(tc.expectedServices, services.Items, cmpopts.EquateEmpty(), svcOpt); diff != "" {
+				t.Errorf("unexpected metrics services (-want +got):\n%s", diff)
+			}
+			for _, svc := range services.Items {
+				if !metav1.IsControlledBy(&svc, extDNS) {
+					t.Errorf("expected metrics service %s to be controlled by externalDNS", svc.Name)
+				}
+			}
+
+			monitors := &unstructured.UnstructuredList{}
+			monitors.SetGroupVersionKind(serviceMonitorGVK.GroupVersion().WithKind(serviceMonitorGVK.Kind + "List"))
+			if err := cl.List(context.TODO(), monitors, client.InNamespace(test.OperandNamespace)); err != nil {
+				t.Fatalf("failed to list service monitors: %v", err)
+			}
+			if tc.expectedMonitorSpec == nil {
+				if len(monitors.Items) != 0 {
+					t.Errorf("expected no service monitors, got %v", monitors.Items)
+				}
+				return
+			}
+			if len(monitors.Items) != 1 {
+				t.Fatalf("expected a single service monitor, got %v", monitors.Items)
+			}
+			if diff := cmp.Diff(tc.expectedMonitorSpec, monitors.Items[0].Object["spec"]); diff != "" {
+				t.Errorf("unexpected service monitor spec (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(labels, monitors.Items[0].GetLabels()); diff != "" {
+				t.Errorf("unexpected service monitor labels (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testOwnedMetricsService(name string, labels map[string]string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       test.OperandNamespace,
+			Labels:          labels,
+			OwnerReferences: testControllerReference(),
+		},
+	}
+}
+
+func testOwnedServiceMonitor(name string, labels map[string]string) *unstructured.Unstructured {
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(serviceMonitorGVK)
+	monitor.SetName(name)
+	monitor.SetNamespace(test.OperandNamespace)
+	monitor.SetLabels(labels)
+	monitor.SetOwnerReferences(testControllerReference())
+	return monitor
+}
+
+func testControllerReference() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion: operatorv1beta1.GroupVersion.String(),
+			Kind:       "ExternalDNS",
+			Name:       test.Name,
+			Controller: ptr.To[bool](true),
+		},
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+)
+
+const (
+	// ownerIDMigrationSyncs is the number of the synchronizations the operand runs
+	// in the migration mode before the migration is considered completed.
+	// ExternalDNS rewrites the ownership of the records in each synchronization,
+	// a few of them cover the failed ones.
+	ownerIDMigrationSyncs = 3
+	// defaultSyncInterval is the default interval between the synchronizations of ExternalDNS.
+	defaultSyncInterval = time.Minute
+	// ownerIDMigrationCheckPeriod is the period of the checks of the migration progress.
+	ownerIDMigrationCheckPeriod = 30 * time.Second
+)
+
+// ownerIDMigrationSource returns the owner ID from which the ownership of the records
+// has to be migrated, empty if no migration is requested or the migration is completed.
+func ownerIDMigrationSource(externalDNS *operatorv1beta1.ExternalDNS) string {
+	registry := externalDNS.Spec.Registry
+	if registry == nil || len(registry.MigrateFromOwnerID) == 0 {
+		return ""
+	}
+	if externalDNS.Status.MigratedFromOwnerID == registry.MigrateFromOwnerID {
+		return ""
+	}
+	return registry.MigrateFromOwnerID
+}
+
+// ownerIDMigrationPeriod returns the time the operand runs in the migration mode
+// before the migration is considered completed: a few synchronization intervals.
+func ownerIDMigrationPeriod(externalDNS *operatorv1beta1.ExternalDNS) time.Duration {
+	interval := defaultSyncInterval
+	if sync := externalDNS.Spec.Sync; sync != nil && sync.Interval != nil && sync.Interval.Duration > 0 {
+		interval = sync.Interval.Duration
+	}
+	return ownerIDMigrationSyncs * interval
+}
+
+// computeOwnerIDMigrationCondition returns the condition of the owner ID migration
+// based on the rollout of the operand deployment in the migration mode and the time spent in this mode.
+// Returns nil if no migration is requested.
+func computeOwnerIDMigrationCondition(externalDNS *operatorv1beta1.ExternalDNS, deployments []*appsv1.Deployment) *metav1.Condition {
+	registry := externalDNS.Spec.Registry
+	if registry == nil || len(registry.MigrateFromOwnerID) == 0 {
+		return nil
+	}
+	from := registry.MigrateFromOwnerID
+
+	cond := &metav1.Condition{
+		Type: ExternalDNSOwnerIDMigratedConditionType,
+	}
+	if externalDNS.Status.MigratedFromOwnerID == from {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "MigrationCompleted"
+		cond.Message = fmt.Sprintf("The ownership of the records was migrated from owner ID %q.", from)
+		return cond
+	}
+
+	if !deploymentsRolledOutWithArg(deployments, migrateFromTXTOwnerArg+from) {
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = "MigrationPending"
+		cond.Message = fmt.Sprintf("Waiting for the deployment to run in the migration mode for owner ID %q.", from)
+		return cond
+	}
+
+	// the transition time of the in progress condition marks the start of the migration
+	if current := findCondition(externalDNS.Status.Conditions, ExternalDNSOwnerIDMigratedConditionType); current != nil &&
+		current.Reason == "MigrationInProgress" && clock.Since(current.LastTransitionTime.Time) >= ownerIDMigrationPeriod(externalDNS) {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "MigrationCompleted"
+		cond.Message = fmt.Sprintf("The ownership of the records was migrated from owner ID %q.", from)
+		return cond
+	}
+
+	cond.Status = metav1.ConditionFalse
+	cond.Reason = "MigrationInProgress"
+	cond.Message = fmt.Sprintf("The ownership of the records is being migrated from owner ID %q.", from)
+	return cond
+}
+
+// deploymentsRolledOutWithArg returns true if there are deployments
+// and all of them are rolled out with the given argument.
+func deploymentsRolledOutWithArg(deployments []*appsv1.Deployment, arg string) bool {
+	if len(deployments) == 0 {
+		return false
+	}
+	for _, deployment := range deployments {
+		if !deploymentRolledOutWithArg(deployment, arg) {
+			return false
+		}
+	}
+	return true
+}
+
+// deploymentRolledOutWithArg returns true if all the replicas of the given deployment are updated and available
+// and all the containers of the deployment have the given argument.
+// The deployment scaled down to zero replicas is never rolled out as none of its containers runs.
+func deploymentRolledOutWithArg(deployment *appsv1.Deployment, arg string) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if replicas == 0 ||
+		deployment.Status.ObservedGeneration < deployment.Generation ||
+		deployment.Status.UpdatedReplicas != replicas ||
+		deployment.Status.AvailableReplicas != replicas {
+		return false
+	}
+
+	containers := operandContainers(deployment.Spec.Template.Spec.Containers)
+	if len(containers) == 0 {
+		return false
+	}
+	for _, container := range containers {
+		found := false
+		for _, a := range container.Args {
+			if a == arg {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// findCondition returns the condition of the given type from the given list, nil if not found.
+func findCondition(conditions []metav1.Condition, condType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == condType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
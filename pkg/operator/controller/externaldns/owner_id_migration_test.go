@@ -0,0 +1,249 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+)
+
+func TestComputeOwnerIDMigrationCondition(t *testing.T) {
+	testCases := []struct {
+		name               string
+		inputExtDNS        *operatorv1beta1.ExternalDNS
+		existingDeployment *appsv1.Deployment
+		expectedCondition  *metav1.Condition
+	}{
+		{
+			name:              "No migration requested",
+			inputExtDNS:       testAWSExternalDNS(operatorv1beta1.SourceTypeService),
+			expectedCondition: nil,
+		},
+		{
+			name:        "Deployment doesn't exist",
+			inputExtDNS: testExternalDNSWithOwnerIDMigration("", nil),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionUnknown,
+				Reason:  "MigrationPending",
+				Message: `Waiting for the deployment to run in the migration mode for owner ID "old-owner".`,
+			},
+		},
+		{
+			name:               "Deployment is not rolled out",
+			inputExtDNS:        testExternalDNSWithOwnerIDMigration("", nil),
+			existingDeployment: testMigrationDeployment(0, "--migrate-from-txt-owner=old-owner"),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionUnknown,
+				Reason:  "MigrationPending",
+				Message: `Waiting for the deployment to run in the migration mode for owner ID "old-owner".`,
+			},
+		},
+		{
+			name:               "Deployment is rolled out without migration",
+			inputExtDNS:        testExternalDNSWithOwnerIDMigration("", nil),
+			existingDeployment: testMigrationDeployment(1, "--txt-owner-id=external-dns-test"),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionUnknown,
+				Reason:  "MigrationPending",
+				Message: `Waiting for the deployment to run in the migration mode for owner ID "old-owner".`,
+			},
+		},
+		{
+			name:        "Deployment is scaled down",
+			inputExtDNS: testExternalDNSWithOwnerIDMigration("", nil),
+			existingDeployment: func() *appsv1.Deployment {
+				depl := testMigrationDeployment(0, "--migrate-from-txt-owner=old-owner")
+				depl.Spec.Replicas = ptr.To[int32](0)
+				depl.Status.UpdatedReplicas = 0
+				return depl
+			}(),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionUnknown,
+				Reason:  "MigrationPending",
+				Message: `Waiting for the deployment to run in the migration mode for owner ID "old-owner".`,
+			},
+		},
+		{
+			name:               "Migration started",
+			inputExtDNS:        testExternalDNSWithOwnerIDMigration("", nil),
+			existingDeployment: testMigrationDeployment(1, "--migrate-from-txt-owner=old-owner"),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "MigrationInProgress",
+				Message: `The ownership of the records is being migrated from owner ID "old-owner".`,
+			},
+		},
+		{
+			name: "Migration is in progress",
+			inputExtDNS: testExternalDNSWithOwnerIDMigration("", &metav1.Condition{
+				Type:               ExternalDNSOwnerIDMigratedConditionType,
+				Status:             metav1.ConditionFalse,
+				Reason:             "MigrationInProgress",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			}),
+			existingDeployment: testMigrationDeployment(1, "--migrate-from-txt-owner=old-owner"),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "MigrationInProgress",
+				Message: `The ownership of the records is being migrated from owner ID "old-owner".`,
+			},
+		},
+		{
+			name: "Migration period is over",
+			inputExtDNS: testExternalDNSWithOwnerIDMigration("", &metav1.Condition{
+				Type:               ExternalDNSOwnerIDMigratedConditionType,
+				Status:             metav1.ConditionFalse,
+				Reason:             "MigrationInProgress",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * ownerIDMigrationSyncs * defaultSyncInterval)),
+			}),
+			existingDeployment: testMigrationDeployment(1, "--migrate-from-txt-owner=old-owner"),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "MigrationCompleted",
+				Message: `The ownership of the records was migrated from owner ID "old-owner".`,
+			},
+		},
+		{
+			name: "Migration is in progress with long sync interval",
+			inputExtDNS: withSyncInterval(testExternalDNSWithOwnerIDMigration("", &metav1.Condition{
+				Type:               ExternalDNSOwnerIDMigratedConditionType,
+				Status:             metav1.ConditionFalse,
+				Reason:             "MigrationInProgress",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * ownerIDMigrationSyncs * defaultSyncInterval)),
+			}), time.Hour),
+			existingDeployment: testMigrationDeployment(1, "--migrate-from-txt-owner=old-owner"),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "MigrationInProgress",
+				Message: `The ownership of the records is being migrated from owner ID "old-owner".`,
+			},
+		},
+		{
+			name: "Migration period with long sync interval is over",
+			inputExtDNS: withSyncInterval(testExternalDNSWithOwnerIDMigration("", &metav1.Condition{
+				Type:               ExternalDNSOwnerIDMigratedConditionType,
+				Status:             metav1.ConditionFalse,
+				Reason:             "MigrationInProgress",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-ownerIDMigrationSyncs * time.Hour)),
+			}), time.Hour),
+			existingDeployment: testMigrationDeployment(1, "--migrate-from-txt-owner=old-owner"),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "MigrationCompleted",
+				Message: `The ownership of the records was migrated from owner ID "old-owner".`,
+			},
+		},
+		{
+			name:               "Migration completed",
+			inputExtDNS:        testExternalDNSWithOwnerIDMigration("old-owner", nil),
+			existingDeployment: testMigrationDeployment(1, "--txt-owner-id=external-dns-test"),
+			expectedCondition: &metav1.Condition{
+				Type:    ExternalDNSOwnerIDMigratedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "MigrationCompleted",
+				Message: `The ownership of the records was migrated from owner ID "old-owner".`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var deployments []*appsv1.Deployment
+			if tc.existingDeployment != nil {
+				deployments = append(deployments, tc.existingDeployment)
+			}
+			cond := computeOwnerIDMigrationCondition(tc.inputExtDNS, deployments)
+			if diff := cmp.Diff(tc.expectedCondition, cond, ignoreTimeOpt); diff != "" {
+				t.Errorf("unexpected condition (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOwnerIDMigrationSource(t *testing.T) {
+	if got := ownerIDMigrationSource(testAWSExternalDNS(operatorv1beta1.SourceTypeService)); got != "" {
+		t.Errorf("expected no owner ID to migrate from, got %q", got)
+	}
+	if got := ownerIDMigrationSource(testExternalDNSWithOwnerIDMigration("", nil)); got != "old-owner" {
+		t.Errorf("expected %q owner ID to migrate from, got %q", "old-owner", got)
+	}
+	if got := ownerIDMigrationSource(testExternalDNSWithOwnerIDMigration("old-owner", nil)); got != "" {
+		t.Errorf("expected no owner ID to migrate from after the migration, got %q", got)
+	}
+}
+
+func testExternalDNSWithOwnerIDMigration(migratedFrom string, cond *metav1.Condition) *operatorv1beta1.ExternalDNS {
+	extdns := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+	extdns.Spec.Registry = &operatorv1beta1.ExternalDNSRegistry{
+		MigrateFromOwnerID: "old-owner",
+	}
+	extdns.Status.MigratedFromOwnerID = migratedFrom
+	if cond != nil {
+		extdns.Status.Conditions = []metav1.Condition{*cond}
+	}
+	return extdns
+}
+
+func withSyncInterval(extdns *operatorv1beta1.ExternalDNS, interval time.Duration) *operatorv1beta1.ExternalDNS {
+	extdns.Spec.Sync = &operatorv1beta1.ExternalDNSSyncOptions{
+		Interval: &metav1.Duration{Duration: interval},
+	}
+	return extdns
+}
+
+func testMigrationDeployment(availableReplicas int32, arg string) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "external-dns",
+							Args: []string{arg},
+						},
+						// the proxy sidecar doesn't run ExternalDNS
+						testMetricsProxyContainer(7979),
+					},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas:   replicas,
+			AvailableReplicas: availableReplicas,
+		},
+	}
+}
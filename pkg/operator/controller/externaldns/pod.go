@@ -25,6 +25,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -41,7 +42,18 @@ const (
 	defaultConfigMountPath        = "/etc/kubernetes"
 	defaultTXTRecordPrefix        = "external-dns-"
 	defaultTXTWildcardReplacement = "any"
+	defaultDynamoDBTable          = "external-dns"
+	registryTXT                   = "txt"
+	registryDynamoDB              = "dynamodb"
+	policySync                    = "sync"
+	policyUpsertOnly              = "upsert-only"
+	policyCreateOnly              = "create-only"
 	providerArg                   = "--provider="
+	zoneIDFilterArg               = "--zone-id-filter="
+	metricsAddressArg             = "--metrics-address="
+	migrateFromTXTOwnerArg        = "--migrate-from-txt-owner="
+	dryRunArg                     = "--dry-run"
+	onceArg                       = "--once"
 	httpProxyEnvVar               = "HTTP_PROXY"
 	httpsProxyEnvVar              = "HTTPS_PROXY"
 	noProxyEnvVar                 = "NO_PROXY"
@@ -54,8 +66,25 @@ const (
 	// SSL_CERT_DIR allows Golang's crypto library to override the default locations.
 	// https://pkg.go.dev/crypto/x509#SystemCertPool
 	sslCertDirEnvVar = "SSL_CERT_DIR"
+	// health endpoint served on the metrics port, probed through the metrics proxy
+	healthzPath = "/healthz"
+	// default timings of the liveness and readiness probes
+	defaultProbeInitialDelaySeconds = 10
+	defaultProbePeriodSeconds       = 10
+	defaultProbeTimeoutSeconds      = 5
+	defaultProbeFailureThreshold    = 3
 	// all capabilities in the container security context
 	allCapabilities = "ALL"
+	// writable volume for the temporary files of the images which need it
+	tmpVolumeName = "tmp"
+	tmpMountPath  = "/tmp"
+	// group owning the volumes of the pod outside of OpenShift,
+	// OpenShift assigns the group from the range of the namespace
+	defaultFSGroup int64 = 65532
+	// AES key of the TXT registry encryption
+	txtEncryptAESKeyEnvVar = "EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY"
+	txtEncryptAESKeyKey    = "EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY"
+	txtEncryptAESKeyLength = 32
 	//
 	// AWS
 	//
@@ -78,6 +107,9 @@ const (
 	azureConfigMountPath  = defaultConfigMountPath
 	azureConfigFileName   = "azure.json"
 	azureConfigFileKey    = "azure.json"
+	// lower case segments of the zone resource ID
+	azureSubscriptionsSegment  = "subscriptions"
+	azureResourceGroupsSegment = "resourcegroups"
 	//
 	// GCP
 	//
@@ -86,6 +118,9 @@ const (
 	gcpCredentialsFileKey    = "gcp-credentials.json"
 	gcpCredentialsFileName   = "gcp-credentials.json"
 	gcpAppCredentialsEnvVar  = "GOOGLE_APPLICATION_CREDENTIALS"
+	// segments of the zone ID qualified with the project
+	gcpProjectsSegment     = "projects"
+	gcpManagedZonesSegment = "managedZones"
 	//
 	// BlueCat
 	//
@@ -100,6 +135,10 @@ const (
 	infobloxWAPIPasswordEnvVar = "EXTERNAL_DNS_INFOBLOX_WAPI_PASSWORD"
 	infobloxWAPIUsernameKey    = "EXTERNAL_DNS_INFOBLOX_WAPI_USERNAME"
 	infobloxWAPIPasswordKey    = "EXTERNAL_DNS_INFOBLOX_WAPI_PASSWORD"
+	infobloxGridCAVolumeName   = "infoblox-grid-ca"
+	infobloxGridCAFileKey      = "ca-bundle.crt"
+	infobloxGridCAFileName     = "infoblox-grid-ca.pem"
+	infobloxGridCAMountPath    = "/etc/pki/infoblox-grid-ca"
 )
 
 // externalDNSContainerBuilder builds the definition of the containers for ExternalDNS POD
@@ -113,6 +152,12 @@ type externalDNSContainerBuilder struct {
 	isOpenShift    bool
 	platformStatus *configv1.PlatformStatus
 	counter        int
+	// providerTarget is the type of the additional provider
+	// for which the containers are built, empty for the primary provider
+	providerTarget operatorv1beta1.ExternalDNSProviderType
+	// txtEncryptionSecretName is the name of the secret with the AES key
+	// of the TXT registry, empty if the encryption is not enabled
+	txtEncryptionSecretName string
 }
 
 // build returns the definition of a single container for the given DNS zone with unique metrics port
@@ -125,7 +170,11 @@ func (b *externalDNSContainerBuilder) build(zone string) (*corev1.Container, err
 // buildSeq returns the definition of a single container for the given DNS zone
 // sequence param is used to create the unique metrics port
 func (b *externalDNSContainerBuilder) buildSeq(seq int, zone string) (*corev1.Container, error) {
-	container := b.defaultContainer(controller.ExternalDNSContainerName(zone))
+	name := controller.ExternalDNSContainerName(zone)
+	if b.providerTarget != "" {
+		name = controller.ExternalDNSProviderContainerName(b.providerTarget, zone)
+	}
+	container := b.defaultContainer(name)
 	err := b.fillProviderAgnosticFields(seq, zone, container)
 	if err != nil {
 		return nil, err
@@ -136,12 +185,17 @@ func (b *externalDNSContainerBuilder) buildSeq(seq int, zone string) (*corev1.Co
 
 // defaultContainer returns the initial definition of any container of ExternalDNS POD
 func (b *externalDNSContainerBuilder) defaultContainer(name string) *corev1.Container {
+	resources := corev1.ResourceRequirements{}
+	if operand := b.externalDNS.Spec.OperandDeployment; operand != nil && operand.Resources != nil {
+		resources = *operand.Resources.DeepCopy()
+	}
 	return &corev1.Container{
 		Name:                     name,
 		Image:                    b.image,
 		Args:                     []string{},
 		ImagePullPolicy:          corev1.PullIfNotPresent,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Resources:                resources,
 		SecurityContext: &corev1.SecurityContext{
 			Capabilities: &corev1.Capabilities{
 				Drop: []corev1.Capability{allCapabilities},
@@ -149,6 +203,7 @@ func (b *externalDNSContainerBuilder) defaultContainer(name string) *corev1.Cont
 			Privileged:               ptr.To[bool](false),
 			RunAsNonRoot:             ptr.To[bool](true),
 			AllowPrivilegeEscalation: ptr.To[bool](false),
+			ReadOnlyRootFilesystem:   ptr.To[bool](true),
 			SeccompProfile: &corev1.SeccompProfile{
 				Type: corev1.SeccompProfileTypeRuntimeDefault,
 			},
@@ -162,17 +217,54 @@ func (b *externalDNSContainerBuilder) fillProviderAgnosticFields(seq int, zone s
 	// ARGS
 	//
 	args := []string{
-		fmt.Sprintf("--metrics-address=%s:%d", defaultMetricsAddress, defaultMetricsStartPort+seq),
-		fmt.Sprintf("--txt-owner-id=%s-%s", defaultOwnerPrefix, b.externalDNS.Name),
+		fmt.Sprintf("%s%s:%d", metricsAddressArg, defaultMetricsAddress, defaultMetricsStartPort+seq),
+		fmt.Sprintf("--txt-owner-id=%s", b.txtOwnerID()),
 		fmt.Sprintf("--provider=%s", b.provider),
 		fmt.Sprintf("--source=%s", b.source),
-		"--policy=sync",
-		"--registry=txt",
-		"--log-level=debug",
+		fmt.Sprintf("--policy=%s", b.policy()),
+		fmt.Sprintf("--registry=%s", b.registry()),
+		fmt.Sprintf("--log-level=%s", operandLogLevel(b.externalDNS, zone)),
+	}
+
+	if logging := b.externalDNS.Spec.Logging; logging != nil && logging.Format == operatorv1beta1.LogFormatJSON {
+		args = append(args, "--log-format=json")
 	}
 
 	if zone != "" {
-		args = append(args, fmt.Sprintf("--zone-id-filter=%s", zone))
+		args = append(args, zoneIDFilterArg+zone)
+	}
+
+	if registry := b.externalDNS.Spec.Registry; registry != nil && len(registry.WildcardReplacement) > 0 {
+		args = append(args, fmt.Sprintf("--txt-wildcard-replacement=%s", registry.WildcardReplacement))
+	}
+
+	if len(b.txtEncryptionSecretName) > 0 {
+		args = append(args, "--txt-encrypt-enabled")
+	}
+
+	// changes waiting for the approval are only planned,
+	// they are applied by the job once approved
+	if b.externalDNS.Spec.DryRun || b.externalDNS.Spec.ChangeApproval == operatorv1beta1.ChangeApprovalManual {
+		args = append(args, dryRunArg)
+	}
+
+	if sync := b.externalDNS.Spec.Sync; sync != nil {
+		if sync.Interval != nil {
+			args = append(args, fmt.Sprintf("--interval=%s", sync.Interval.Duration))
+		}
+		if sync.Events {
+			args = append(args, "--events")
+			if sync.MinEventSyncInterval != nil {
+				args = append(args, fmt.Sprintf("--min-event-sync-interval=%s", sync.MinEventSyncInterval.Duration))
+			}
+		}
+		if sync.MinTTL != nil {
+			args = append(args, fmt.Sprintf("--min-ttl=%s", sync.MinTTL.Duration))
+		}
+	}
+
+	if from := ownerIDMigrationSource(b.externalDNS); len(from) > 0 {
+		args = append(args, fmt.Sprintf("%s%s", migrateFromTXTOwnerArg, from))
 	}
 
 	if b.externalDNS.Spec.Source.LabelFilter != nil {
@@ -223,9 +315,27 @@ func (b *externalDNSContainerBuilder) fillProviderAgnosticFields(seq int, zone s
 	container.Args = append(container.Args, filterArgs...)
 	container.Args = append(container.Args, args...)
 
+	if overrides := b.externalDNS.Spec.UnsupportedOperandOverrides; overrides != nil {
+		container.Args = append(container.Args, overrides.Args...)
+	}
+
 	//
 	// ENV
 	//
+	if len(b.txtEncryptionSecretName) > 0 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: txtEncryptAESKeyEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: b.txtEncryptionSecretName,
+					},
+					Key: txtEncryptAESKeyKey,
+				},
+			},
+		})
+	}
+
 	if utils.EnvProxySupportedProvider(b.externalDNS) {
 		if val := os.Getenv(httpProxyEnvVar); val != "" {
 			container.Env = append(container.Env, corev1.EnvVar{Name: httpProxyEnvVar, Value: val})
@@ -238,6 +348,18 @@ func (b *externalDNSContainerBuilder) fillProviderAgnosticFields(seq int, zone s
 		}
 	}
 
+	if overrides := b.externalDNS.Spec.UnsupportedOperandOverrides; overrides != nil {
+		for _, env := range overrides.Env {
+			container.Env = append(container.Env, *env.DeepCopy())
+		}
+	}
+
+	//
+	// PROBES
+	//
+	container.LivenessProbe = b.probe(defaultMetricsStartPort + seq)
+	container.ReadinessProbe = b.probe(defaultMetricsStartPort + seq)
+
 	//
 	// VOLUME MOUNTS
 	//
@@ -251,6 +373,13 @@ func (b *externalDNSContainerBuilder) fillProviderAgnosticFields(seq int, zone s
 			})
 			container.Env = append(container.Env, corev1.EnvVar{Name: sslCertDirEnvVar, Value: trustedCAExtractedPEMDir})
 		}
+		// if writable tmp volume was added
+		if v.Name == tmpVolumeName {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      v.Name,
+				MountPath: tmpMountPath,
+			})
+		}
 	}
 
 	return nil
@@ -328,17 +457,19 @@ func (b *externalDNSContainerBuilder) fillProviderSpecificFields(zone string, co
 	case externalDNSProviderTypeAzure, externalDNSProviderTypeAzurePrivate:
 		b.fillAzureFields(zone, container)
 	case externalDNSProviderTypeGCP:
-		b.fillGCPFields(container)
+		b.fillGCPFields(zone, container)
 	case externalDNSProviderTypeBlueCat:
 		b.fillBlueCatFields(container)
 	case externalDNSProviderTypeInfoblox:
 		b.fillInfobloxFields(container)
+	case externalDNSProviderTypeInMemory:
+		b.fillInMemoryFields(zone, container)
 	}
 }
 
 // fillAWSFields fills the given container with the data specific to AWS provider
 func (b *externalDNSContainerBuilder) fillAWSFields(container *corev1.Container) {
-	container.Args = addTXTPrefixFlag(container.Args)
+	container.Args = b.addTXTAffixFlag(container.Args)
 
 	if b.platformStatus != nil && b.platformStatus.AWS != nil && utils.IsUSGovAWSRegion(b.platformStatus.AWS.Region) {
 		// See https://github.com/kubernetes-sigs/external-dns/blob/master/docs/tutorials/aws.md#govcloud-caveats
@@ -346,6 +477,13 @@ func (b *externalDNSContainerBuilder) fillAWSFields(container *corev1.Container)
 		container.Args = append(container.Args, "--aws-prefer-cname")
 	}
 
+	if b.registry() == registryDynamoDB {
+		container.Args = append(container.Args, fmt.Sprintf("--dynamodb-table=%s", dynamoDBTable(b.externalDNS)))
+		if region := dynamoDBRegion(b.externalDNS, b.platformStatus); len(region) > 0 {
+			container.Args = append(container.Args, fmt.Sprintf("--dynamodb-region=%s", region))
+		}
+	}
+
 	if b.externalDNS.Spec.Provider.AWS != nil && b.externalDNS.Spec.Provider.AWS.AssumeRole != nil {
 		container.Args = append(container.Args, fmt.Sprintf("--aws-assume-role=%s", b.externalDNS.Spec.Provider.AWS.AssumeRole.ARN))
 	}
@@ -377,10 +515,12 @@ func (b *externalDNSContainerBuilder) fillAWSFields(container *corev1.Container)
 // fillAzureFields fills the given container with the data specific to Azure provider
 func (b *externalDNSContainerBuilder) fillAzureFields(zone string, container *corev1.Container) {
 	// https://github.com/kubernetes-sigs/external-dns/issues/2082
-	container.Args = addTXTPrefixFlag(container.Args)
+	container.Args = b.addTXTAffixFlag(container.Args)
 
 	// https://github.com/kubernetes-sigs/external-dns/issues/2922
-	container.Args = append(container.Args, fmt.Sprintf("--txt-wildcard-replacement=%s", defaultTXTWildcardReplacement))
+	if registry := b.externalDNS.Spec.Registry; registry == nil || len(registry.WildcardReplacement) == 0 {
+		container.Args = append(container.Args, fmt.Sprintf("--txt-wildcard-replacement=%s", defaultTXTWildcardReplacement))
+	}
 
 	// check the zone field for the keyword 'privatednszones', this ensures that the
 	// provider 'azure-private-dns' is passed to the container
@@ -393,6 +533,15 @@ func (b *externalDNSContainerBuilder) fillAzureFields(zone string, container *co
 			}
 		}
 	}
+
+	// the zone's resource ID takes precedence over the subscription and the resource group
+	// from the config file, this allows the zones from different subscriptions in one instance
+	if subscription, resourceGroup := splitAzureZoneID(zone); subscription != "" && resourceGroup != "" {
+		container.Args = append(container.Args,
+			fmt.Sprintf("--azure-subscription-id=%s", subscription),
+			fmt.Sprintf("--azure-resource-group=%s", resourceGroup),
+		)
+	}
 	// no volume mounts will be added if there is no config volume added before
 	for _, v := range b.volumes {
 		// config volume
@@ -408,11 +557,21 @@ func (b *externalDNSContainerBuilder) fillAzureFields(zone string, container *co
 }
 
 // fillGCPFields fills the given container with the data specific to Google provider
-func (b *externalDNSContainerBuilder) fillGCPFields(container *corev1.Container) {
+func (b *externalDNSContainerBuilder) fillGCPFields(zone string, container *corev1.Container) {
 	// https://github.com/kubernetes-sigs/external-dns/issues/262
-	container.Args = addTXTPrefixFlag(container.Args)
+	container.Args = b.addTXTAffixFlag(container.Args)
 
-	if !b.isOpenShift {
+	// the project from the zone ID takes precedence over the one from the spec or the platform,
+	// the zone ID filter is reset to the name of the managed zone as the operand doesn't know the qualified form
+	if project, managedZone := splitGCPZoneID(zone); project != "" {
+		for i, x := range container.Args {
+			if strings.HasPrefix(x, zoneIDFilterArg) {
+				container.Args[i] = zoneIDFilterArg + managedZone
+				break
+			}
+		}
+		container.Args = append(container.Args, fmt.Sprintf("--google-project=%s", project))
+	} else if !b.isOpenShift {
 		// don't add empty args if GCP provider is not given
 		if b.externalDNS.Spec.Provider.GCP == nil {
 			return
@@ -422,7 +581,12 @@ func (b *externalDNSContainerBuilder) fillGCPFields(container *corev1.Container)
 			container.Args = append(container.Args, fmt.Sprintf("--google-project=%s", *b.externalDNS.Spec.Provider.GCP.Project))
 		}
 	} else {
-		if b.platformStatus != nil && b.platformStatus.GCP != nil && len(b.platformStatus.GCP.ProjectID) > 0 {
+		// the additional provider doesn't run on the platform's project necessarily,
+		// the platform status doesn't even have the GCP details on the other platforms
+		gcp := b.externalDNS.Spec.Provider.GCP
+		if b.providerTarget != "" && gcp != nil && gcp.Project != nil && len(*gcp.Project) > 0 {
+			container.Args = append(container.Args, fmt.Sprintf("--google-project=%s", *gcp.Project))
+		} else if b.platformStatus != nil && b.platformStatus.GCP != nil && len(b.platformStatus.GCP.ProjectID) > 0 {
 			container.Args = append(container.Args, fmt.Sprintf("--google-project=%s", b.platformStatus.GCP.ProjectID))
 		}
 	}
@@ -444,7 +608,7 @@ func (b *externalDNSContainerBuilder) fillGCPFields(container *corev1.Container)
 func (b *externalDNSContainerBuilder) fillBlueCatFields(container *corev1.Container) {
 	// only standard CNAME records are supported
 	// https://docs.bluecatnetworks.com/r/Address-Manager-API-Guide/ENUM-number-generic-methods/9.2.0
-	container.Args = addTXTPrefixFlag(container.Args)
+	container.Args = b.addTXTAffixFlag(container.Args)
 
 	// no volume mounts will be added if there is no config volume added before
 	for _, v := range b.volumes {
@@ -460,6 +624,20 @@ func (b *externalDNSContainerBuilder) fillBlueCatFields(container *corev1.Contai
 	}
 }
 
+// fillInMemoryFields fills the given container with the data specific to InMemory provider
+func (b *externalDNSContainerBuilder) fillInMemoryFields(zone string, container *corev1.Container) {
+	// the zones of the in-memory provider are created empty when the operand starts,
+	// the zone name is used as the zone ID by the provider
+	if len(zone) != 0 {
+		container.Args = append(container.Args, fmt.Sprintf("--inmemory-zone=%s", zone))
+	}
+
+	// the records are not shared with anyone, the affix is added only if it's given explicitly
+	if registry := b.externalDNS.Spec.Registry; registry != nil && (len(registry.TXTPrefix) > 0 || len(registry.TXTSuffix) > 0) {
+		container.Args = b.addTXTAffixFlag(container.Args)
+	}
+}
+
 // fillInfobloxFields fills the given container with the data specific to Infoblox provider
 func (b *externalDNSContainerBuilder) fillInfobloxFields(container *corev1.Container) {
 	// don't add empty args or env vars if secret or infoblox provider is not given
@@ -479,7 +657,25 @@ func (b *externalDNSContainerBuilder) fillInfobloxFields(container *corev1.Conta
 		args = append(args, fmt.Sprintf("--infoblox-wapi-version=%s", b.externalDNS.Spec.Provider.Infoblox.WAPIVersion))
 	}
 
-	args = addTXTPrefixFlag(args)
+	if len(b.externalDNS.Spec.Provider.Infoblox.View) > 0 {
+		args = append(args, fmt.Sprintf("--infoblox-view=%s", b.externalDNS.Spec.Provider.Infoblox.View))
+	}
+
+	if b.externalDNS.Spec.Provider.Infoblox.CreatePTR {
+		args = append(args, "--infoblox-create-ptr")
+	}
+
+	if b.externalDNS.Spec.Provider.Infoblox.MaxResults > 0 {
+		args = append(args, fmt.Sprintf("--infoblox-max-results=%d", b.externalDNS.Spec.Provider.Infoblox.MaxResults))
+	}
+
+	if b.externalDNS.Spec.Provider.Infoblox.SkipTLSVerify {
+		args = append(args, "--no-infoblox-ssl-verify")
+	} else {
+		args = append(args, "--infoblox-ssl-verify")
+	}
+
+	args = b.addTXTAffixFlag(args)
 
 	env := []corev1.EnvVar{
 		{
@@ -508,21 +704,49 @@ func (b *externalDNSContainerBuilder) fillInfobloxFields(container *corev1.Conta
 
 	container.Args = append(container.Args, args...)
 	container.Env = append(container.Env, env...)
+
+	// no volume mounts will be added if there is no grid CA volume added before
+	for _, v := range b.volumes {
+		if v.Name == infobloxGridCAVolumeName {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      v.Name,
+				MountPath: infobloxGridCAMountPath,
+				ReadOnly:  true,
+			})
+			addCertDir(container, infobloxGridCAMountPath)
+		}
+	}
+}
+
+// addCertDir adds the given directory to the list of directories
+// from which the operand loads the trusted certificates.
+func addCertDir(container *corev1.Container, dir string) {
+	for i, env := range container.Env {
+		if env.Name == sslCertDirEnvVar {
+			// SSL_CERT_DIR is a colon separated list of directories
+			container.Env[i].Value = env.Value + ":" + dir
+			return
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: sslCertDirEnvVar, Value: dir})
 }
 
 // externalDNSVolumeBuilder builds the definition of the volumes for ExternalDNS POD
 type externalDNSVolumeBuilder struct {
-	provider               string
-	secretName             string
-	trustedCAConfigMapName string
+	provider                    string
+	secretName                  string
+	trustedCAConfigMapName      string
+	infobloxGridCAConfigMapName string
+	writableTmp                 bool
 }
 
 // newExternalDNSVolumeBuilder returns an instance of volume builder
-func newExternalDNSVolumeBuilder(provider, secretName, trustedCAConfigMapName string) *externalDNSVolumeBuilder {
+func newExternalDNSVolumeBuilder(provider, secretName, trustedCAConfigMapName, infobloxGridCAConfigMapName string) *externalDNSVolumeBuilder {
 	return &externalDNSVolumeBuilder{
-		provider:               provider,
-		secretName:             secretName,
-		trustedCAConfigMapName: trustedCAConfigMapName,
+		provider:                    provider,
+		secretName:                  secretName,
+		trustedCAConfigMapName:      trustedCAConfigMapName,
+		infobloxGridCAConfigMapName: infobloxGridCAConfigMapName,
 	}
 }
 
@@ -534,27 +758,34 @@ func (b *externalDNSVolumeBuilder) build() []corev1.Volume {
 
 // providerAgnosticVolumes returns the volumes ...
 func (b *externalDNSVolumeBuilder) providerAgnosticVolumes() []corev1.Volume {
+	var volumes []corev1.Volume
 	if len(b.trustedCAConfigMapName) > 0 {
-		return []corev1.Volume{
-			{
-				Name: trustedCAVolumeName,
-				VolumeSource: corev1.VolumeSource{
-					ConfigMap: &corev1.ConfigMapVolumeSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: b.trustedCAConfigMapName,
-						},
-						Items: []corev1.KeyToPath{
-							{
-								Key:  trustedCAFileKey,
-								Path: trustedCAFileName,
-							},
+		volumes = append(volumes, corev1.Volume{
+			Name: trustedCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: b.trustedCAConfigMapName,
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  trustedCAFileKey,
+							Path: trustedCAFileName,
 						},
 					},
 				},
 			},
-		}
+		})
 	}
-	return nil
+	if b.writableTmp {
+		volumes = append(volumes, corev1.Volume{
+			Name: tmpVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+	return volumes
 }
 
 // providerSpecificVolumes returns the volumes specific to the provider of given External DNS
@@ -568,6 +799,8 @@ func (b *externalDNSVolumeBuilder) providerSpecificVolumes() []corev1.Volume {
 		return b.gcpVolumes()
 	case externalDNSProviderTypeBlueCat:
 		return b.bluecatVolumes()
+	case externalDNSProviderTypeInfoblox:
+		return b.infobloxVolumes()
 	}
 	return nil
 }
@@ -681,8 +914,159 @@ func (b *externalDNSVolumeBuilder) bluecatVolumes() []corev1.Volume {
 	}
 }
 
-// addTXTPrefixFlag adds the txt prefix flag with default value
+// infobloxVolumes returns volumes needed for Infoblox provider
+func (b *externalDNSVolumeBuilder) infobloxVolumes() []corev1.Volume {
+	if len(b.infobloxGridCAConfigMapName) == 0 {
+		return nil
+	}
+
+	return []corev1.Volume{
+		{
+			Name: infobloxGridCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: b.infobloxGridCAConfigMapName,
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  infobloxGridCAFileKey,
+							Path: infobloxGridCAFileName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// splitGCPZoneID returns the project and the managed zone from the given zone ID
+// if the zone ID is qualified with the project: "projects/<project>/managedZones/<zone>".
+// Returns empty strings otherwise.
+func splitGCPZoneID(zone string) (string, string) {
+	parts := strings.Split(zone, "/")
+	if len(parts) != 4 || parts[0] != gcpProjectsSegment || parts[2] != gcpManagedZonesSegment || parts[1] == "" || parts[3] == "" {
+		return "", ""
+	}
+	return parts[1], parts[3]
+}
+
+// splitAzureZoneID returns the subscription and the resource group from the given Azure zone resource ID:
+// "/subscriptions/<subscription>/resourceGroups/<resource group>/providers/Microsoft.Network/<dnszones|privateDnsZones>/<zone>".
+// Returns empty strings if the zone is not given as a resource ID.
+func splitAzureZoneID(zone string) (string, string) {
+	subscription, resourceGroup := "", ""
+	parts := strings.Split(zone, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		switch strings.ToLower(parts[i]) {
+		case azureSubscriptionsSegment:
+			subscription = parts[i+1]
+		case azureResourceGroupsSegment:
+			resourceGroup = parts[i+1]
+		}
+	}
+	return subscription, resourceGroup
+}
+
+// addTXTAffixFlag adds the txt suffix or prefix flag from the registry settings,
+// the txt prefix flag with default value is added if none is given,
 // needed if CNAME records are used: https://github.com/kubernetes-sigs/external-dns#note
-func addTXTPrefixFlag(args []string) []string {
+func (b *externalDNSContainerBuilder) addTXTAffixFlag(args []string) []string {
+	if registry := b.externalDNS.Spec.Registry; registry != nil {
+		if len(registry.TXTSuffix) > 0 {
+			return append(args, fmt.Sprintf("--txt-suffix=%s", registry.TXTSuffix))
+		}
+		if len(registry.TXTPrefix) > 0 {
+			return append(args, fmt.Sprintf("--txt-prefix=%s", registry.TXTPrefix))
+		}
+	}
 	return append(args, fmt.Sprintf("--txt-prefix=%s", defaultTXTRecordPrefix))
 }
+
+// probe returns the probe of the health endpoint served on the given metrics port
+// with the timings from the operand deployment.
+// The metrics port is bound to the loopback, the kubelet reaches the endpoint through the metrics proxy.
+func (b *externalDNSContainerBuilder) probe(port int) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   healthzPath,
+				Port:   intstr.FromInt32(metricsProxyPort(int32(port))),
+				Scheme: corev1.URISchemeHTTPS,
+			},
+		},
+		InitialDelaySeconds: defaultProbeInitialDelaySeconds,
+		PeriodSeconds:       defaultProbePeriodSeconds,
+		TimeoutSeconds:      defaultProbeTimeoutSeconds,
+		FailureThreshold:    defaultProbeFailureThreshold,
+		SuccessThreshold:    1,
+	}
+	operand := b.externalDNS.Spec.OperandDeployment
+	if operand == nil || operand.Probes == nil {
+		return probe
+	}
+	if operand.Probes.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *operand.Probes.InitialDelaySeconds
+	}
+	if operand.Probes.PeriodSeconds != nil {
+		probe.PeriodSeconds = *operand.Probes.PeriodSeconds
+	}
+	if operand.Probes.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *operand.Probes.TimeoutSeconds
+	}
+	if operand.Probes.FailureThreshold != nil {
+		probe.FailureThreshold = *operand.Probes.FailureThreshold
+	}
+	return probe
+}
+
+// policy returns the policy argument from the synchronization policy,
+// the deletions guarded by the deletion guard are applied by its jobs
+func (b *externalDNSContainerBuilder) policy() string {
+	switch b.externalDNS.Spec.Policy {
+	case operatorv1beta1.PolicyUpsertOnly:
+		return policyUpsertOnly
+	case operatorv1beta1.PolicyCreateOnly:
+		return policyCreateOnly
+	}
+	if deletionGuardActive(b.externalDNS) {
+		return policyUpsertOnly
+	}
+	return policySync
+}
+
+// registry returns the registry argument from the registry settings
+func (b *externalDNSContainerBuilder) registry() string {
+	if registry := b.externalDNS.Spec.Registry; registry != nil && registry.Type == operatorv1beta1.RegistryTypeDynamoDB {
+		return registryDynamoDB
+	}
+	return registryTXT
+}
+
+// dynamoDBTable returns the name of the DynamoDB table from the registry settings or the default one
+func dynamoDBTable(externalDNS *operatorv1beta1.ExternalDNS) string {
+	if registry := externalDNS.Spec.Registry; registry != nil && registry.DynamoDB != nil && len(registry.DynamoDB.Table) > 0 {
+		return registry.DynamoDB.Table
+	}
+	return defaultDynamoDBTable
+}
+
+// dynamoDBRegion returns the region of the DynamoDB table from the registry settings,
+// the cluster's region is used if none is given
+func dynamoDBRegion(externalDNS *operatorv1beta1.ExternalDNS, platformStatus *configv1.PlatformStatus) string {
+	if registry := externalDNS.Spec.Registry; registry != nil && registry.DynamoDB != nil && len(registry.DynamoDB.Region) > 0 {
+		return registry.DynamoDB.Region
+	}
+	if platformStatus != nil && platformStatus.AWS != nil {
+		return platformStatus.AWS.Region
+	}
+	return ""
+}
+
+// txtOwnerID returns the owner ID from the registry settings or the default one
+func (b *externalDNSContainerBuilder) txtOwnerID() string {
+	if registry := b.externalDNS.Spec.Registry; registry != nil && len(registry.OwnerID) > 0 {
+		return registry.OwnerID
+	}
+	return fmt.Sprintf("%s-%s", defaultOwnerPrefix, b.externalDNS.Name)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// podSecurityEnforceLabel is the label of the namespace setting the Pod Security Admission level enforced on the pods.
+	podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	// podSecurityRestricted is the most restrictive Pod Security Standard the ExternalDNS pods comply with.
+	podSecurityRestricted = "restricted"
+)
+
+// ensureOperandNamespacePodSecurity ensures that the operand namespace
+// enforces the restricted Pod Security Standard on its pods.
+// The level set by the cluster admin is left as is.
+func (r *reconciler) ensureOperandNamespacePodSecurity(ctx context.Context) error {
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: r.config.Namespace}, ns); err != nil {
+		return fmt.Errorf("failed to get operand namespace %s: %w", r.config.Namespace, err)
+	}
+
+	if _, found := ns.Labels[podSecurityEnforceLabel]; found {
+		return nil
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[podSecurityEnforceLabel] = podSecurityRestricted
+	if err := r.client.Patch(ctx, ns, patch); err != nil {
+		return fmt.Errorf("failed to label operand namespace %s for pod security: %w", ns.Name, err)
+	}
+	r.log.Info("labeled operand namespace for pod security", "name", ns.Name, "level", podSecurityRestricted)
+	return nil
+}
@@ -5,8 +5,11 @@ import (
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
+	configv1 "github.com/openshift/api/config/v1"
+
 	"github.com/openshift/external-dns-operator/api/v1beta1"
 )
 
@@ -260,3 +263,158 @@ func TestDomainFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitGCPZoneID(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		zone                string
+		expectedProject     string
+		expectedManagedZone string
+	}{
+		{
+			name: "zone name",
+			zone: "my-zone",
+		},
+		{
+			name: "numeric zone id",
+			zone: "3651032588905568971",
+		},
+		{
+			name:                "zone qualified with project",
+			zone:                "projects/hub-project/managedZones/hub-zone",
+			expectedProject:     "hub-project",
+			expectedManagedZone: "hub-zone",
+		},
+		{
+			name: "zone qualified with empty project",
+			zone: "projects//managedZones/hub-zone",
+		},
+		{
+			name: "unknown collection",
+			zone: "projects/hub-project/zones/hub-zone",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			project, managedZone := splitGCPZoneID(tc.zone)
+			if project != tc.expectedProject {
+				t.Errorf("expected project %q, got %q", tc.expectedProject, project)
+			}
+			if managedZone != tc.expectedManagedZone {
+				t.Errorf("expected managed zone %q, got %q", tc.expectedManagedZone, managedZone)
+			}
+		})
+	}
+}
+
+func TestGCPProjectArg(t *testing.T) {
+	gcpPlatform := &configv1.PlatformStatus{
+		Type: configv1.GCPPlatformType,
+		GCP:  &configv1.GCPPlatformStatus{ProjectID: "platform-project"},
+	}
+	awsPlatform := &configv1.PlatformStatus{
+		Type: configv1.AWSPlatformType,
+		AWS:  &configv1.AWSPlatformStatus{Region: "us-east-1"},
+	}
+	for _, tc := range []struct {
+		name            string
+		project         *string
+		providerTarget  v1beta1.ExternalDNSProviderType
+		platformStatus  *configv1.PlatformStatus
+		expectedProject string
+	}{
+		{
+			name:            "primary provider uses platform project",
+			project:         ptr.To[string]("spec-project"),
+			platformStatus:  gcpPlatform,
+			expectedProject: "platform-project",
+		},
+		{
+			name:            "additional provider uses its project",
+			project:         ptr.To[string]("spec-project"),
+			providerTarget:  v1beta1.ProviderTypeGCP,
+			platformStatus:  gcpPlatform,
+			expectedProject: "spec-project",
+		},
+		{
+			name:            "additional provider on non GCP platform uses its project",
+			project:         ptr.To[string]("spec-project"),
+			providerTarget:  v1beta1.ProviderTypeGCP,
+			platformStatus:  awsPlatform,
+			expectedProject: "spec-project",
+		},
+		{
+			name:            "additional provider without project falls back to platform project",
+			providerTarget:  v1beta1.ProviderTypeGCP,
+			platformStatus:  gcpPlatform,
+			expectedProject: "platform-project",
+		},
+		{
+			name:           "additional provider without project on non GCP platform",
+			providerTarget: v1beta1.ProviderTypeGCP,
+			platformStatus: awsPlatform,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &externalDNSContainerBuilder{
+				externalDNS: &v1beta1.ExternalDNS{
+					Spec: v1beta1.ExternalDNSSpec{
+						Provider: v1beta1.ExternalDNSProvider{
+							Type: v1beta1.ProviderTypeGCP,
+							GCP:  &v1beta1.ExternalDNSGCPProviderOptions{Project: tc.project},
+						},
+					},
+				},
+				isOpenShift:    true,
+				platformStatus: tc.platformStatus,
+				providerTarget: tc.providerTarget,
+			}
+			container := &corev1.Container{}
+			b.fillGCPFields("my-zone", container)
+			project := ""
+			for _, arg := range container.Args {
+				if strings.HasPrefix(arg, "--google-project=") {
+					project = strings.TrimPrefix(arg, "--google-project=")
+				}
+			}
+			if project != tc.expectedProject {
+				t.Errorf("expected project %q, got %q", tc.expectedProject, project)
+			}
+		})
+	}
+}
+
+func TestSplitAzureZoneID(t *testing.T) {
+	for _, tc := range []struct {
+		name                  string
+		zone                  string
+		expectedSubscription  string
+		expectedResourceGroup string
+	}{
+		{
+			name: "zone name",
+			zone: "example.com",
+		},
+		{
+			name:                  "public zone resource id",
+			zone:                  "/subscriptions/hub/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+			expectedSubscription:  "hub",
+			expectedResourceGroup: "dns-rg",
+		},
+		{
+			name:                  "private zone resource id with lower case segments",
+			zone:                  "/subscriptions/spoke/resourcegroups/cluster-rg/providers/Microsoft.Network/privateDnsZones/example.com",
+			expectedSubscription:  "spoke",
+			expectedResourceGroup: "cluster-rg",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			subscription, resourceGroup := splitAzureZoneID(tc.zone)
+			if subscription != tc.expectedSubscription {
+				t.Errorf("expected subscription %q, got %q", tc.expectedSubscription, subscription)
+			}
+			if resourceGroup != tc.expectedResourceGroup {
+				t.Errorf("expected resource group %q, got %q", tc.expectedResourceGroup, resourceGroup)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	operatorutils "github.com/openshift/external-dns-operator/pkg/utils"
+)
+
+const (
+	// recordCleanupFinalizer holds the deletion of ExternalDNS
+	// until the DNS records it owns are deleted.
+	recordCleanupFinalizer = "externaldns.olm.openshift.io/record-cleanup"
+	// recordCleanupCheckPeriod is the period of the checks of the record cleanup progress.
+	recordCleanupCheckPeriod = 15 * time.Second
+
+	// emptySourceArg makes ExternalDNS see no endpoints,
+	// with the sync policy all the records owned by the instance are deleted.
+	emptySourceArg  = "--source=empty"
+	sourceArgPrefix = "--source="
+	policyArgPrefix = "--policy="
+
+	recordCleanupStartedEventReason = "RecordCleanupStarted"
+	recordCleanupFailedEventReason  = "RecordCleanupFailed"
+)
+
+// ensureExternalDNSFinalizer adds the record cleanup finalizer to the given externalDNS
+// if its records have to be deleted along with it, the finalizer is removed otherwise.
+// The dependencies of the record cleanup are released before the finalizer is removed
+// not to be left behind with the finalizer once the externalDNS is deleted.
+func (r *reconciler) ensureExternalDNSFinalizer(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) error {
+	desired := externalDNS.Spec.DeletionPolicy == operatorv1beta1.DeletionPolicyDelete
+	if desired == controllerutil.ContainsFinalizer(externalDNS, recordCleanupFinalizer) {
+		return nil
+	}
+	if !desired {
+		if err := r.ensureRecordCleanupDependencies(ctx, externalDNS, false); err != nil {
+			return err
+		}
+	}
+
+	patch := client.MergeFromWithOptions(externalDNS.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if desired {
+		controllerutil.AddFinalizer(externalDNS, recordCleanupFinalizer)
+	} else {
+		controllerutil.RemoveFinalizer(externalDNS, recordCleanupFinalizer)
+	}
+	if err := r.client.Patch(ctx, externalDNS, patch); err != nil {
+		return fmt.Errorf("failed to update the finalizers of externalDNS %s: %w", externalDNS.Name, err)
+	}
+	r.log.Info("updated the finalizers of externalDNS", "name", externalDNS.Name, "finalizers", externalDNS.Finalizers)
+	return nil
+}
+
+// finalizeExternalDNS deletes the records owned by the given externalDNS which is being deleted
+// and removes the record cleanup finalizer once the records are deleted.
+// The finalizer is removed right away if the records have to be orphaned.
+// Returns a Boolean value indicating whether the finalizer was removed, and an error when relevant.
+func (r *reconciler) finalizeExternalDNS(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) (bool, error) {
+	if !controllerutil.ContainsFinalizer(externalDNS, recordCleanupFinalizer) {
+		return true, nil
+	}
+
+	if externalDNS.Spec.DeletionPolicy == operatorv1beta1.DeletionPolicyDelete {
+		cleaned, err := r.cleanupExternalDNSRecords(ctx, externalDNS)
+		if err != nil || !cleaned {
+			return false, err
+		}
+	}
+	if err := r.ensureRecordCleanupDependencies(ctx, externalDNS, false); err != nil {
+		return false, err
+	}
+
+	patch := client.MergeFromWithOptions(externalDNS.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(externalDNS, recordCleanupFinalizer)
+	if err := r.client.Patch(ctx, externalDNS, patch); err != nil {
+		return false, fmt.Errorf("failed to remove the finalizer of externalDNS %s: %w", externalDNS.Name, err)
+	}
+	r.log.Info("removed the record cleanup finalizer of externalDNS", "name", externalDNS.Name)
+	return true, nil
+}
+
+// cleanupExternalDNSRecords scales the operand deployments down not to recreate the records
+// and runs the job which deletes the records owned by the given externalDNS.
+// The job is built from the desired deployments as the current ones may already be garbage collected,
+// it's not controlled by the externalDNS not to be garbage collected along with them.
+// Returns a Boolean value indicating whether the records were deleted, and an error when relevant.
+func (r *reconciler) cleanupExternalDNSRecords(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) (bool, error) {
+	jobName := controller.ExternalDNSDestRecordCleanupJobName(r.config.Namespace, externalDNS.Name)
+	job := &batchv1.Job{}
+	if err := r.client.Get(ctx, jobName, job); err == nil {
+		return r.checkRecordCleanupJob(ctx, externalDNS, job)
+	} else if !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get record cleanup job %s: %w", jobName, err)
+	}
+
+	deployments, err := r.currentExternalDNSDeployments(ctx, r.config.Namespace, externalDNS)
+	if err != nil {
+		return false, fmt.Errorf("failed to get externalDNS deployments: %w", err)
+	}
+	if len(deployments) == 0 && !externalDNS.Status.OperandStarted {
+		// the operand never ran, it didn't create any record
+		r.log.Info("externalDNS operand never started; record cleanup will be skipped", "name", externalDNS.Name)
+		return true, nil
+	}
+
+	// the operand would recreate the records deleted by the job
+	scaledDown := true
+	for _, deployment := range deployments {
+		if !deployment.DeletionTimestamp.IsZero() {
+			// the pods are being deleted along with the deployment
+			scaledDown = false
+			continue
+		}
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+			updated := deployment.DeepCopy()
+			updated.Spec.Replicas = ptr.To[int32](0)
+			if err := r.client.Update(ctx, updated); err != nil {
+				return false, fmt.Errorf("failed to scale down externalDNS deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
+			}
+			r.log.Info("scaled down externalDNS deployment for record cleanup", "namespace", deployment.Namespace, "name", deployment.Name)
+			scaledDown = false
+		} else if deployment.Status.Replicas != 0 {
+			scaledDown = false
+		}
+	}
+	if !scaledDown {
+		return false, nil
+	}
+
+	desiredDepls, err := r.desiredRecordCleanupDeployments(externalDNS)
+	if err != nil {
+		return false, err
+	}
+	desired := desiredRecordCleanupJob(externalDNS, desiredDepls, jobName)
+	if err := r.client.Create(ctx, desired); err != nil {
+		return false, fmt.Errorf("failed to create record cleanup job %s: %w", jobName, err)
+	}
+	r.log.Info("created record cleanup job", "namespace", desired.Namespace, "name", desired.Name)
+	r.recorder.Eventf(externalDNS, corev1.EventTypeNormal, recordCleanupStartedEventReason, "The DNS records are being deleted by job %s/%s", desired.Namespace, desired.Name)
+	return false, nil
+}
+
+// desiredRecordCleanupDeployments returns the desired operand deployments of the given externalDNS.
+// The dependencies are referenced by their names only,
+// they are kept until the end of the record cleanup by its finalizer.
+func (r *reconciler) desiredRecordCleanupDeployments(externalDNS *operatorv1beta1.ExternalDNS) ([]*appsv1.Deployment, error) {
+	cfg := &deploymentConfig{
+		namespace:  r.config.Namespace,
+		image:      r.config.Image,
+		proxyImage: r.config.ProxyImage,
+		serviceAccount: &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.config.Namespace,
+				Name:      controller.ExternalDNSResourceName(externalDNS),
+			},
+		},
+		externalDNS:            externalDNS,
+		isOpenShift:            r.config.IsOpenShift,
+		platformStatus:         r.config.PlatformStatus,
+		additionalSecretHashes: map[operatorv1beta1.ExternalDNSProviderType]string{},
+	}
+	if operatorutils.CredentialsRequiredProvider(externalDNS) {
+		cfg.secret = controller.ExternalDNSDestCredentialsSecretName(r.config.Namespace, externalDNS.Name).Name
+	}
+	if r.config.InjectTrustedCA {
+		cfg.trustedCAConfigMapName = controller.ExternalDNSDestTrustedCAConfigMapName(r.config.Namespace).Name
+	}
+	if controller.ExternalDNSInfobloxCAConfigMapNameFromProvider(externalDNS) != "" {
+		cfg.infobloxGridCAConfigMapName = controller.ExternalDNSDestInfobloxCAConfigMapName(r.config.Namespace, externalDNS.Name).Name
+	}
+	if controller.ExternalDNSTXTEncryptionSecretNameFromRegistry(externalDNS) != "" {
+		cfg.txtEncryptionSecretName = controller.ExternalDNSDestTXTEncryptionSecretName(r.config.Namespace, externalDNS.Name).Name
+	}
+
+	depls, err := desiredExternalDNSDeployments(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build externalDNS deployment for record cleanup: %w", err)
+	}
+	return depls, nil
+}
+
+// recordCleanupDependencies returns the objects of the operand namespace the record cleanup job needs.
+// They are controlled by the given externalDNS and would be garbage collected before the record cleanup otherwise.
+func (r *reconciler) recordCleanupDependencies(externalDNS *operatorv1beta1.ExternalDNS) []client.Object {
+	deps := []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: r.config.Namespace, Name: controller.ExternalDNSResourceName(externalDNS)}},
+	}
+	secret := func(nsName types.NamespacedName) client.Object {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: nsName.Namespace, Name: nsName.Name}}
+	}
+	if operatorutils.CredentialsRequiredProvider(externalDNS) {
+		deps = append(deps, secret(controller.ExternalDNSDestCredentialsSecretName(r.config.Namespace, externalDNS.Name)))
+	}
+	for _, target := range externalDNS.Spec.AdditionalProviders {
+		if operatorutils.CredentialsRequiredProvider(operatorutils.ExternalDNSForProviderTarget(externalDNS, target)) {
+			deps = append(deps, secret(controller.ExternalDNSDestProviderCredentialsSecretName(r.config.Namespace, externalDNS.Name, target.Type)))
+		}
+	}
+	if controller.ExternalDNSTXTEncryptionSecretNameFromRegistry(externalDNS) != "" {
+		deps = append(deps, secret(controller.ExternalDNSDestTXTEncryptionSecretName(r.config.Namespace, externalDNS.Name)))
+	}
+	if controller.ExternalDNSInfobloxCAConfigMapNameFromProvider(externalDNS) != "" {
+		nsName := controller.ExternalDNSDestInfobloxCAConfigMapName(r.config.Namespace, externalDNS.Name)
+		deps = append(deps, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: nsName.Namespace, Name: nsName.Name}})
+	}
+	return deps
+}
+
+// ensureRecordCleanupDependencies adds the record cleanup finalizer to the existing dependencies of the record cleanup
+// of the given externalDNS if they have to be protected, the finalizer is removed otherwise.
+func (r *reconciler) ensureRecordCleanupDependencies(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, protect bool) error {
+	for _, dep := range r.recordCleanupDependencies(externalDNS) {
+		nsName := client.ObjectKeyFromObject(dep)
+		if err := r.client.Get(ctx, nsName, dep); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get record cleanup dependency %s: %w", nsName, err)
+		}
+		if protect == controllerutil.ContainsFinalizer(dep, recordCleanupFinalizer) {
+			continue
+		}
+
+		patch := client.MergeFrom(dep.DeepCopyObject().(client.Object))
+		if protect {
+			controllerutil.AddFinalizer(dep, recordCleanupFinalizer)
+		} else {
+			controllerutil.RemoveFinalizer(dep, recordCleanupFinalizer)
+		}
+		if err := r.client.Patch(ctx, dep, patch); err != nil {
+			return fmt.Errorf("failed to update the finalizers of record cleanup dependency %s: %w", nsName, err)
+		}
+		r.log.Info("updated the finalizers of record cleanup dependency", "namespace", nsName.Namespace, "name", nsName.Name, "finalizers", dep.GetFinalizers())
+	}
+	return nil
+}
+
+// checkRecordCleanupJob returns true once the given record cleanup job completed, the job is deleted then.
+// The failed job is deleted to be retried.
+func (r *reconciler) checkRecordCleanupJob(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, job *batchv1.Job) (bool, error) {
+	finished, failed, message := jobResult(job)
+	if !finished {
+		return false, nil
+	}
+	// the job is not garbage collected along with the externalDNS
+	if err := r.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete record cleanup job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	if failed {
+		r.recorder.Eventf(externalDNS, corev1.EventTypeWarning, recordCleanupFailedEventReason, "The record cleanup job %s/%s failed, it will be retried: %s", job.Namespace, job.Name, message)
+		return false, fmt.Errorf("record cleanup job %s/%s failed: %s", job.Namespace, job.Name, message)
+	}
+	return true, nil
+}
+
+// desiredRecordCleanupJob returns the job which runs the operand containers once
+// without any source and with the sync policy, so that all the records owned by the instance are deleted.
+// The job is not controlled by the externalDNS not to be garbage collected along with it.
+func desiredRecordCleanupJob(externalDNS *operatorv1beta1.ExternalDNS, deployments []*appsv1.Deployment, nsName types.NamespacedName) *batchv1.Job {
+	labels := map[string]string{
+		changeRequestExternalDNSLabel: externalDNS.Name,
+	}
+	template := oneShotOperandTemplate(deployments, labels, func(arg string) (string, bool) {
+		switch {
+		case arg == dryRunArg:
+			return arg, false
+		case strings.HasPrefix(arg, sourceArgPrefix):
+			return emptySourceArg, true
+		case strings.HasPrefix(arg, policyArgPrefix):
+			return policyArgPrefix + policySync, true
+		}
+		return arg, true
+	})
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsName.Name,
+			Namespace: nsName.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](0),
+			Template:     *template,
+		},
+	}
+}
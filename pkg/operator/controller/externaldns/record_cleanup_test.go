@@ -0,0 +1,406 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+const (
+	testRecordCleanupJobName        = "external-dns-cleanup-test"
+	testRecordCleanupCredSecretName = "external-dns-credentials-test"
+)
+
+func TestEnsureExternalDNSFinalizer(t *testing.T) {
+	testCases := []struct {
+		name               string
+		deletionPolicy     operatorv1beta1.ExternalDNSDeletionPolicy
+		existingFinalizers []string
+		expectedFinalizers []string
+	}{
+		{
+			name:           "Records are orphaned",
+			deletionPolicy: operatorv1beta1.DeletionPolicyOrphan,
+		},
+		{
+			name:               "Records are deleted",
+			deletionPolicy:     operatorv1beta1.DeletionPolicyDelete,
+			expectedFinalizers: []string{recordCleanupFinalizer},
+		},
+		{
+			name:               "Records are no longer deleted",
+			deletionPolicy:     operatorv1beta1.DeletionPolicyOrphan,
+			existingFinalizers: []string{recordCleanupFinalizer},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			extDNS.Spec.DeletionPolicy = tc.deletionPolicy
+			extDNS.Finalizers = tc.existingFinalizers
+
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(extDNS).Build()
+			r := &reconciler{
+				client: cl,
+				scheme: test.Scheme,
+				log:    zap.New(zap.UseDevMode(true)),
+			}
+
+			if err := r.ensureExternalDNSFinalizer(context.TODO(), extDNS); err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+
+			got := &operatorv1beta1.ExternalDNS{}
+			if err := cl.Get(context.TODO(), types.NamespacedName{Name: test.Name}, got); err != nil {
+				t.Fatalf("failed to get externalDNS: %v", err)
+			}
+			if !cmp.Equal(tc.expectedFinalizers, got.Finalizers) {
+				t.Errorf("expected finalizers %v, got %v", tc.expectedFinalizers, got.Finalizers)
+			}
+		})
+	}
+}
+
+func TestFinalizeExternalDNS(t *testing.T) {
+	deployment := func(replicas, statusReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      test.OperandName,
+				Namespace: test.OperandNamespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](replicas),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							appNameLabel:     ExternalDNSBaseName,
+							appInstanceLabel: test.Name,
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "external-dns-zone1",
+								Image: test.OperandImage,
+								Args:  []string{"--provider=aws", "--source=service", "--policy=upsert-only", "--service-type-filter=LoadBalancer"},
+							},
+						},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas: statusReplicas,
+			},
+		}
+	}
+	// the deployment is garbage collected by the foreground deletion of the externalDNS
+	deletedDeployment := deployment(0, 1)
+	deletedDeployment.DeletionTimestamp = &metav1.Time{Time: clock.Now()}
+	deletedDeployment.Finalizers = []string{metav1.FinalizerDeleteDependents}
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       test.OperandName,
+			Namespace:  test.OperandNamespace,
+			Finalizers: []string{recordCleanupFinalizer},
+		},
+	}
+	credSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testRecordCleanupCredSecretName,
+			Namespace:  test.OperandNamespace,
+			Finalizers: []string{recordCleanupFinalizer},
+		},
+	}
+	cleanupArgs := []string{
+		"--metrics-address=127.0.0.1:7979",
+		"--txt-owner-id=external-dns-test",
+		"--provider=aws",
+		"--source=empty",
+		"--policy=sync",
+		"--registry=txt",
+		"--log-level=debug",
+		"--zone-id-filter=my-dns-public-zone",
+		"--service-type-filter=NodePort",
+		"--service-type-filter=LoadBalancer",
+		"--service-type-filter=ClusterIP",
+		"--service-type-filter=ExternalName",
+		"--publish-internal-services",
+		"--ignore-hostname-annotation",
+		"--fqdn-template={{.Name}}.test.com",
+		"--txt-prefix=external-dns-",
+		"--once",
+	}
+	cleanupJob := func(condType batchv1.JobConditionType) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testRecordCleanupJobName,
+				Namespace: test.OperandNamespace,
+			},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{
+						Type:   condType,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                   string
+		deletionPolicy         operatorv1beta1.ExternalDNSDeletionPolicy
+		operandStarted         bool
+		existingObjects        []runtime.Object
+		errExpected            bool
+		expectedRemoved        bool
+		expectedReplicas       *int32
+		expectedJobArgs        []string
+		expectedJobDeleted     bool
+		expectedDepsFinalizers []string
+	}{
+		{
+			name:            "Records are orphaned",
+			deletionPolicy:  operatorv1beta1.DeletionPolicyOrphan,
+			existingObjects: []runtime.Object{deployment(1, 1), serviceAccount, credSecret},
+			expectedRemoved: true,
+		},
+		{
+			name:            "Operand never ran",
+			deletionPolicy:  operatorv1beta1.DeletionPolicyDelete,
+			existingObjects: []runtime.Object{serviceAccount, credSecret},
+			expectedRemoved: true,
+		},
+		{
+			name:                   "Operand deployments are being garbage collected",
+			deletionPolicy:         operatorv1beta1.DeletionPolicyDelete,
+			operandStarted:         true,
+			existingObjects:        []runtime.Object{deletedDeployment, serviceAccount, credSecret},
+			expectedDepsFinalizers: []string{recordCleanupFinalizer},
+		},
+		{
+			name:                   "Operand deployments were garbage collected",
+			deletionPolicy:         operatorv1beta1.DeletionPolicyDelete,
+			operandStarted:         true,
+			existingObjects:        []runtime.Object{serviceAccount, credSecret},
+			expectedJobArgs:        cleanupArgs,
+			expectedDepsFinalizers: []string{recordCleanupFinalizer},
+		},
+		{
+			name:             "Operand is scaled down",
+			deletionPolicy:   operatorv1beta1.DeletionPolicyDelete,
+			existingObjects:  []runtime.Object{deployment(1, 1)},
+			expectedReplicas: ptr.To[int32](0),
+		},
+		{
+			name:             "Operand pods are terminating",
+			deletionPolicy:   operatorv1beta1.DeletionPolicyDelete,
+			existingObjects:  []runtime.Object{deployment(0, 1)},
+			expectedReplicas: ptr.To[int32](0),
+		},
+		{
+			name:             "Cleanup job is started",
+			deletionPolicy:   operatorv1beta1.DeletionPolicyDelete,
+			existingObjects:  []runtime.Object{deployment(0, 0)},
+			expectedReplicas: ptr.To[int32](0),
+			expectedJobArgs:  cleanupArgs,
+		},
+		{
+			name:               "Cleanup job completed",
+			deletionPolicy:     operatorv1beta1.DeletionPolicyDelete,
+			existingObjects:    []runtime.Object{deployment(0, 0), cleanupJob(batchv1.JobComplete), serviceAccount, credSecret},
+			expectedRemoved:    true,
+			expectedJobDeleted: true,
+		},
+		{
+			name:                   "Cleanup job failed",
+			deletionPolicy:         operatorv1beta1.DeletionPolicyDelete,
+			existingObjects:        []runtime.Object{deployment(0, 0), cleanupJob(batchv1.JobFailed), serviceAccount, credSecret},
+			errExpected:            true,
+			expectedJobDeleted:     true,
+			expectedDepsFinalizers: []string{recordCleanupFinalizer},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			extDNS.Spec.DeletionPolicy = tc.deletionPolicy
+			extDNS.Finalizers = []string{recordCleanupFinalizer}
+			extDNS.DeletionTimestamp = &metav1.Time{Time: clock.Now()}
+			extDNS.Status.OperandStarted = tc.operandStarted
+
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(append(tc.existingObjects, extDNS)...).Build()
+			r := &reconciler{
+				config: Config{
+					Namespace:         test.OperandNamespace,
+					OperatorNamespace: test.OperatorNamespace,
+				},
+				client:   cl,
+				scheme:   test.Scheme,
+				log:      zap.New(zap.UseDevMode(true)),
+				recorder: record.NewFakeRecorder(10),
+			}
+
+			removed, err := r.finalizeExternalDNS(context.TODO(), extDNS)
+			if tc.errExpected && err == nil {
+				t.Fatal("expected an error but got none")
+			} else if !tc.errExpected && err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+			if removed != tc.expectedRemoved {
+				t.Errorf("expected the finalizer removal to be %t, got %t", tc.expectedRemoved, removed)
+			}
+
+			if tc.expectedReplicas != nil {
+				gotDepl := &appsv1.Deployment{}
+				if err := cl.Get(context.TODO(), types.NamespacedName{Namespace: test.OperandNamespace, Name: test.OperandName}, gotDepl); err != nil {
+					t.Fatalf("failed to get the deployment: %v", err)
+				}
+				if !cmp.Equal(tc.expectedReplicas, gotDepl.Spec.Replicas) {
+					t.Errorf("expected deployment replicas %d, got %v", *tc.expectedReplicas, gotDepl.Spec.Replicas)
+				}
+			}
+
+			testRecordCleanupDependenciesFinalizers(t, cl, tc.expectedDepsFinalizers)
+
+			job := &batchv1.Job{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Namespace: test.OperandNamespace, Name: testRecordCleanupJobName}, job)
+			if tc.expectedJobDeleted {
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected the finished cleanup job to be deleted, got error: %v", err)
+				}
+			}
+			if tc.expectedJobArgs == nil {
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get the cleanup job: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedJobArgs, job.Spec.Template.Spec.Containers[0].Args); diff != "" {
+				t.Errorf("unexpected cleanup job args (-want +got):\n%s", diff)
+			}
+			if _, found := job.Spec.Template.Labels[appInstanceLabel]; found {
+				t.Errorf("expected cleanup job pods not to be labeled as operand pods, got labels %v", job.Spec.Template.Labels)
+			}
+			if len(job.OwnerReferences) != 0 {
+				t.Errorf("expected cleanup job not to be garbage collected along with externalDNS, got owner references %v", job.OwnerReferences)
+			}
+			if job.Spec.Template.Spec.ServiceAccountName != test.OperandName {
+				t.Errorf("expected cleanup job service account %q, got %q", test.OperandName, job.Spec.Template.Spec.ServiceAccountName)
+			}
+		})
+	}
+}
+
+func TestEnsureRecordCleanupDependencies(t *testing.T) {
+	testCases := []struct {
+		name               string
+		protect            bool
+		existingFinalizers []string
+		expectedFinalizers []string
+	}{
+		{
+			name:               "Dependencies are protected",
+			protect:            true,
+			expectedFinalizers: []string{recordCleanupFinalizer},
+		},
+		{
+			name:               "Dependencies are already protected",
+			protect:            true,
+			existingFinalizers: []string{recordCleanupFinalizer},
+			expectedFinalizers: []string{recordCleanupFinalizer},
+		},
+		{
+			name:               "Dependencies are released",
+			existingFinalizers: []string{recordCleanupFinalizer},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNS(operatorv1beta1.SourceTypeService)
+			// the credentials secret is not synced yet
+			existing := []runtime.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       test.OperandName,
+						Namespace:  test.OperandNamespace,
+						Finalizers: tc.existingFinalizers,
+					},
+				},
+			}
+
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(existing...).Build()
+			r := &reconciler{
+				config: Config{
+					Namespace:         test.OperandNamespace,
+					OperatorNamespace: test.OperatorNamespace,
+				},
+				client: cl,
+				scheme: test.Scheme,
+				log:    zap.New(zap.UseDevMode(true)),
+			}
+
+			if err := r.ensureRecordCleanupDependencies(context.TODO(), extDNS, tc.protect); err != nil {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+			testRecordCleanupDependenciesFinalizers(t, cl, tc.expectedFinalizers)
+		})
+	}
+}
+
+// testRecordCleanupDependenciesFinalizers checks the finalizers of the existing record cleanup dependencies.
+func testRecordCleanupDependenciesFinalizers(t *testing.T, cl client.Client, expected []string) {
+	t.Helper()
+	deps := []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: test.OperandNamespace, Name: test.OperandName}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: test.OperandNamespace, Name: testRecordCleanupCredSecretName}},
+	}
+	for _, dep := range deps {
+		if err := cl.Get(context.TODO(), client.ObjectKeyFromObject(dep), dep); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			t.Fatalf("failed to get %s: %v", client.ObjectKeyFromObject(dep), err)
+		}
+		if !cmp.Equal(expected, dep.GetFinalizers(), cmpopts.EquateEmpty()) {
+			t.Errorf("expected finalizers %v of %s, got %v", expected, client.ObjectKeyFromObject(dep), dep.GetFinalizers())
+		}
+	}
+}
@@ -19,17 +19,26 @@ package externaldnscontroller
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
 	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	operatorutils "github.com/openshift/external-dns-operator/pkg/utils"
 )
 
+// imagePullSecretsAnnotation lists the image pull secrets of the service account set by the operator.
+const imagePullSecretsAnnotation = "externaldns.olm.openshift.io/image-pull-secrets"
+
 // ensureExternalDNSServiceAccount ensures that the externalDNS service account exists.
 func (r *reconciler) ensureExternalDNSServiceAccount(ctx context.Context, namespace string, externalDNS *operatorv1beta1.ExternalDNS) (bool, *corev1.ServiceAccount, error) {
 	nsName := types.NamespacedName{Namespace: namespace, Name: controller.ExternalDNSResourceName(externalDNS)}
@@ -52,6 +61,12 @@ func (r *reconciler) ensureExternalDNSServiceAccount(ctx context.Context, namesp
 		return r.currentExternalDNSServiceAccount(ctx, nsName)
 	}
 
+	if updated, err := r.updateExternalDNSServiceAccount(ctx, current, desired); err != nil {
+		return true, current, err
+	} else if updated {
+		return r.currentExternalDNSServiceAccount(ctx, nsName)
+	}
+
 	return true, current, nil
 }
 
@@ -69,12 +84,98 @@ func (r *reconciler) currentExternalDNSServiceAccount(ctx context.Context, nsNam
 
 // desiredExternalDNSServiceAccount returns the desired serivce account resource.
 func desiredExternalDNSServiceAccount(namespace string, externalDNS *operatorv1beta1.ExternalDNS) *corev1.ServiceAccount {
-	return &corev1.ServiceAccount{
+	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
 			Name:      controller.ExternalDNSResourceName(externalDNS),
 		},
+		// the token is mounted only into the ExternalDNS pods which request it
+		AutomountServiceAccountToken: ptr.To[bool](false),
+	}
+
+	if operand := externalDNS.Spec.Operand; operand != nil && len(operand.ImagePullSecrets) > 0 {
+		names := make([]string, 0, len(operand.ImagePullSecrets))
+		for _, secret := range operand.ImagePullSecrets {
+			names = append(names, secret.Name)
+		}
+		sa.Annotations = map[string]string{
+			imagePullSecretsAnnotation: strings.Join(names, ","),
+		}
+		sa.ImagePullSecrets = append(sa.ImagePullSecrets, operand.ImagePullSecrets...)
+	}
+
+	operatorutils.ApplyOperandMetadata(externalDNS, &sa.ObjectMeta)
+
+	return sa
+}
+
+// updateExternalDNSServiceAccount updates the image pull secrets of the current service account
+// if they don't match the desired ones.
+// Returns a Boolean value indicating whether the service account was updated, and an error when relevant.
+func (r *reconciler) updateExternalDNSServiceAccount(ctx context.Context, current, desired *corev1.ServiceAccount) (bool, error) {
+	changed, updated := externalDNSServiceAccountChanged(current, desired)
+	if !changed {
+		return false, nil
+	}
+
+	if err := r.client.Update(ctx, updated); err != nil {
+		return false, fmt.Errorf("failed to update externalDNS service account %s/%s: %w", updated.Namespace, updated.Name, err)
+	}
+	r.log.Info("updated externalDNS service account", "namespace", updated.Namespace, "name", updated.Name)
+	return true, nil
+}
+
+// externalDNSServiceAccountChanged checks that the current service account matches the expected one.
+// The custom labels and annotations of the operand and the automount of the token are reconciled as well.
+// Only the image pull secrets previously set by the operator are replaced,
+// the secrets added by others (e.g. the dockercfg secret added by OpenShift) are kept.
+// Returns a Boolean value indicating whether the service account has to be updated, and the updated service account.
+func externalDNSServiceAccountChanged(current, expected *corev1.ServiceAccount) (bool, *corev1.ServiceAccount) {
+	managed := map[string]bool{}
+	for _, name := range strings.Split(current.Annotations[imagePullSecretsAnnotation], ",") {
+		managed[name] = true
+	}
+	desired := map[string]bool{}
+	for _, secret := range expected.ImagePullSecrets {
+		desired[secret.Name] = true
+	}
+
+	pullSecrets := []corev1.LocalObjectReference{}
+	present := map[string]bool{}
+	for _, secret := range current.ImagePullSecrets {
+		if managed[secret.Name] && !desired[secret.Name] {
+			continue
+		}
+		pullSecrets = append(pullSecrets, secret)
+		present[secret.Name] = true
+	}
+	for _, secret := range expected.ImagePullSecrets {
+		if !present[secret.Name] {
+			pullSecrets = append(pullSecrets, secret)
+		}
+	}
+
+	updated := current.DeepCopy()
+	metadataChanged := operatorutils.OperandMetadataChanged(&current.ObjectMeta, &expected.ObjectMeta, &updated.ObjectMeta)
+
+	currentAnnotation, currentFound := current.Annotations[imagePullSecretsAnnotation]
+	expectedAnnotation, expectedFound := expected.Annotations[imagePullSecretsAnnotation]
+	automountChanged := !equalBoolPtr(current.AutomountServiceAccountToken, expected.AutomountServiceAccountToken)
+	if !metadataChanged && !automountChanged && currentFound == expectedFound && currentAnnotation == expectedAnnotation && cmp.Equal(pullSecrets, current.ImagePullSecrets, cmpopts.EquateEmpty()) {
+		return false, nil
+	}
+
+	updated.ImagePullSecrets = pullSecrets
+	updated.AutomountServiceAccountToken = expected.AutomountServiceAccountToken
+	if expectedFound {
+		if updated.Annotations == nil {
+			updated.Annotations = map[string]string{}
+		}
+		updated.Annotations[imagePullSecretsAnnotation] = expectedAnnotation
+	} else {
+		delete(updated.Annotations, imagePullSecretsAnnotation)
 	}
+	return true, updated
 }
 
 // createExternalDNSServiceAccount creates the given service account using the reconciler's client.
@@ -26,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -35,8 +36,30 @@ import (
 )
 
 func TestEnsureExternalDNSServiceAccount(t *testing.T) {
+	ownerRefs := []metav1.OwnerReference{
+		{
+			APIVersion:         operatorv1beta1.GroupVersion.String(),
+			Kind:               "ExternalDNS",
+			Name:               test.ExternalDNS.Name,
+			Controller:         &test.TrueVar,
+			BlockOwnerDeletion: &test.TrueVar,
+		},
+	}
+	openShiftPullSecret := corev1.LocalObjectReference{Name: "external-dns-test-dockercfg-abcde"}
+	registryPullSecret := corev1.LocalObjectReference{Name: "registry-credentials"}
+	extDNSWithPullSecrets := test.ExternalDNS.DeepCopy()
+	extDNSWithPullSecrets.Spec.Operand = &operatorv1beta1.ExternalDNSOperand{
+		ImagePullSecrets: []corev1.LocalObjectReference{registryPullSecret},
+	}
+	extDNSWithOperandMetadata := test.ExternalDNS.DeepCopy()
+	extDNSWithOperandMetadata.Spec.OperandMetadata = &operatorv1beta1.ExternalDNSOperandMetadata{
+		Labels:      map[string]string{"cost-center": "dns"},
+		Annotations: map[string]string{"backup.example.com/exclude": "true"},
+	}
+
 	testCases := []struct {
 		name            string
+		inputExtDNS     *operatorv1beta1.ExternalDNS
 		existingObjects []runtime.Object
 		expectedExist   bool
 		expectedSA      corev1.ServiceAccount
@@ -60,6 +83,7 @@ func TestEnsureExternalDNSServiceAccount(t *testing.T) {
 						},
 					},
 				},
+				AutomountServiceAccountToken: ptr.To[bool](false),
 			},
 		},
 		{
@@ -96,19 +120,133 @@ func TestEnsureExternalDNSServiceAccount(t *testing.T) {
 						},
 					},
 				},
+				AutomountServiceAccountToken: ptr.To[bool](false),
+			},
+		},
+		{
+			name:        "Image pull secrets are added",
+			inputExtDNS: extDNSWithPullSecrets,
+			existingObjects: []runtime.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            controller.ExternalDNSResourceName(test.ExternalDNS),
+						Namespace:       test.OperandNamespace,
+						OwnerReferences: ownerRefs,
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{openShiftPullSecret},
+				},
+			},
+			expectedExist: true,
+			expectedSA: corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            controller.ExternalDNSResourceName(test.ExternalDNS),
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+					Annotations: map[string]string{
+						imagePullSecretsAnnotation: registryPullSecret.Name,
+					},
+				},
+				AutomountServiceAccountToken: ptr.To[bool](false),
+				ImagePullSecrets:             []corev1.LocalObjectReference{openShiftPullSecret, registryPullSecret},
+			},
+		},
+		{
+			name: "Image pull secrets are removed",
+			existingObjects: []runtime.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            controller.ExternalDNSResourceName(test.ExternalDNS),
+						Namespace:       test.OperandNamespace,
+						OwnerReferences: ownerRefs,
+						Annotations: map[string]string{
+							imagePullSecretsAnnotation: registryPullSecret.Name,
+						},
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{openShiftPullSecret, registryPullSecret},
+				},
+			},
+			expectedExist: true,
+			expectedSA: corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            controller.ExternalDNSResourceName(test.ExternalDNS),
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				AutomountServiceAccountToken: ptr.To[bool](false),
+				ImagePullSecrets:             []corev1.LocalObjectReference{openShiftPullSecret},
+			},
+		},
+		{
+			name:        "Custom operand metadata is added",
+			inputExtDNS: extDNSWithOperandMetadata,
+			existingObjects: []runtime.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            controller.ExternalDNSResourceName(test.ExternalDNS),
+						Namespace:       test.OperandNamespace,
+						OwnerReferences: ownerRefs,
+						Labels:          map[string]string{"team": "network"},
+					},
+				},
+			},
+			expectedExist: true,
+			expectedSA: corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            controller.ExternalDNSResourceName(test.ExternalDNS),
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+					Labels:          map[string]string{"team": "network", "cost-center": "dns"},
+					Annotations: map[string]string{
+						"backup.example.com/exclude":                       "true",
+						"externaldns.olm.openshift.io/operand-labels":      "cost-center",
+						"externaldns.olm.openshift.io/operand-annotations": "backup.example.com/exclude",
+					},
+				},
+				AutomountServiceAccountToken: ptr.To[bool](false),
+			},
+		},
+		{
+			name: "Custom operand metadata is removed",
+			existingObjects: []runtime.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            controller.ExternalDNSResourceName(test.ExternalDNS),
+						Namespace:       test.OperandNamespace,
+						OwnerReferences: ownerRefs,
+						Labels:          map[string]string{"team": "network", "cost-center": "dns"},
+						Annotations: map[string]string{
+							"backup.example.com/exclude":                       "true",
+							"externaldns.olm.openshift.io/operand-labels":      "cost-center",
+							"externaldns.olm.openshift.io/operand-annotations": "backup.example.com/exclude",
+						},
+					},
+				},
+			},
+			expectedExist: true,
+			expectedSA: corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            controller.ExternalDNSResourceName(test.ExternalDNS),
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+					Labels:          map[string]string{"team": "network"},
+				},
+				AutomountServiceAccountToken: ptr.To[bool](false),
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			if tc.inputExtDNS == nil {
+				tc.inputExtDNS = test.ExternalDNS
+			}
 			cl := fake.NewClientBuilder().WithRuntimeObjects(tc.existingObjects...).Build()
 			r := &reconciler{
 				client: cl,
 				scheme: test.Scheme,
 				log:    zap.New(zap.UseDevMode(true)),
 			}
-			gotExist, gotSA, err := r.ensureExternalDNSServiceAccount(context.TODO(), test.OperandNamespace, test.ExternalDNS)
+			gotExist, gotSA, err := r.ensureExternalDNSServiceAccount(context.TODO(), test.OperandNamespace, tc.inputExtDNS)
 			if err != nil {
 				if !tc.errExpected {
 					t.Fatalf("unexpected error received: %v", err)
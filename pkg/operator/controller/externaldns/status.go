@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -17,6 +18,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controlleroperator "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	operatorutils "github.com/openshift/external-dns-operator/pkg/utils"
 )
 
 const (
@@ -25,27 +28,48 @@ const (
 	ExternalDNSDeploymentReplicasMinAvailableConditionType = "DeploymentReplicasMinAvailable"
 	ExternalDNSDeploymentReplicasAllAvailableConditionType = "DeploymentReplicasAllAvailable"
 	ExternalDNSCredentialsSecretExistsConditionType        = "CredentialsSecretExists"
+	// ExternalDNSContainersReadyConditionType reports the containers failing the probes.
+	ExternalDNSContainersReadyConditionType = "ContainersReady"
+	// ExternalDNSProviderAvailableConditionTypeSuffix is the suffix of the condition type
+	// reported for each provider when the records are mirrored to additional providers.
+	// The condition type is prefixed with the provider type, e.g. "AWSProviderAvailable".
+	ExternalDNSProviderAvailableConditionTypeSuffix = "ProviderAvailable"
+	// ExternalDNSOwnerIDMigratedConditionType is reported only when the owner ID migration is requested.
+	ExternalDNSOwnerIDMigratedConditionType = "OwnerIDMigrated"
+	// ExternalDNSChangesBlockedConditionType is reported only when the deletion guard is enabled.
+	ExternalDNSChangesBlockedConditionType = "ChangesBlocked"
+	// ExternalDNSPausedConditionType is reported only when the instance is paused.
+	ExternalDNSPausedConditionType = "Paused"
+	// ExternalDNSUnsupportedOperandOverridesConditionType is reported only when the unsupported operand overrides are set.
+	ExternalDNSUnsupportedOperandOverridesConditionType = "UnsupportedOperandOverrides"
 )
 
 // clock is to enable unit testing
 var clock utilclock.WithTickerAndDelayedExecution = utilclock.RealClock{}
 
 // updateExternalDNSStatus updates the status of the given externaldns instance with
-// the status of the operand deployment and the credentials secret.
-func (r *reconciler) updateExternalDNSStatus(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, currentDeployment *appsv1.Deployment, secretExists bool) error {
+// the status of the operand deployments, the credentials secret and the deletion guard.
+func (r *reconciler) updateExternalDNSStatus(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, currentDeployments []*appsv1.Deployment, secretExists bool, deletionGuard *operatorv1beta1.ExternalDNSDeletionGuardStatus) error {
 	extDNSWithStatus := externalDNS.DeepCopy()
-	// deployment
-	if currentDeployment != nil {
+	// deployments
+	if externalDNS.Spec.Paused {
+		// no pods are expected to run while paused
+		extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, pausedDeploymentConditions()...)
+	} else if len(currentDeployments) != 0 {
 		extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions,
-			computeDeploymentAvailableCondition(currentDeployment),
-			computeMinReplicasCondition(currentDeployment),
-			computeAllReplicasCondition(currentDeployment),
-			computeDeploymentPodsScheduledCondition(ctx, r.client, currentDeployment),
+			computeDeploymentsConditions(ctx, r.client, currentDeployments)...,
 		)
 	}
+	extDNSWithStatus.Status.ZoneDeployments = computeZoneDeploymentStatuses(ctx, r.client, extDNSWithStatus, currentDeployments)
+	// the record cleanup needs to know whether the operand may have published records
+	// once the deployments are garbage collected
+	extDNSWithStatus.Status.OperandStarted = externalDNS.Status.OperandStarted || len(currentDeployments) != 0
 	// credentials secret
 	secretExistsCond := createCredentialsSecretExistsCondition()
-	if !secretExists {
+	if !operatorutils.CredentialsRequiredProvider(externalDNS) {
+		secretExistsCond.Reason = "SecretNotRequired"
+		secretExistsCond.Message = "The provider doesn't require the credentials secret."
+	} else if !secretExists {
 		secretExistsCond.Status = metav1.ConditionFalse
 		secretExistsCond.Reason = "SecretNotFound"
 		// we don't show the name of the secret deliberately
@@ -54,6 +78,59 @@ func (r *reconciler) updateExternalDNSStatus(ctx context.Context, externalDNS *o
 		secretExistsCond.Message = "The credentials secret not found."
 	}
 	extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, secretExistsCond)
+	// providers
+	providerConds := r.computeProviderAvailableConditions(ctx, externalDNS, currentDeployments, secretExists)
+	if externalDNS.Spec.Paused {
+		for i := range providerConds {
+			// the missing secret is still worth reporting
+			if providerConds[i].Reason != "SecretNotFound" {
+				providerConds[i] = pausedCondition(providerConds[i].Type)
+			}
+		}
+	}
+	extDNSWithStatus.Status.Conditions = mergeConditions(removeStaleProviderConditions(extDNSWithStatus.Status.Conditions, providerConds), providerConds...)
+
+	// owner ID migration
+	if migrationCond := computeOwnerIDMigrationCondition(externalDNS, currentDeployments); migrationCond != nil {
+		extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, *migrationCond)
+		if migrationCond.Status == metav1.ConditionTrue {
+			extDNSWithStatus.Status.MigratedFromOwnerID = externalDNS.Spec.Registry.MigrateFromOwnerID
+		}
+	} else {
+		extDNSWithStatus.Status.Conditions = removeConditions(extDNSWithStatus.Status.Conditions, ExternalDNSOwnerIDMigratedConditionType)
+		extDNSWithStatus.Status.MigratedFromOwnerID = ""
+	}
+
+	// dry run
+	extDNSWithStatus.Status.DryRun = r.computeDryRunStatus(ctx, externalDNS)
+
+	// deletion guard
+	if guardCond := computeDeletionGuardCondition(externalDNS, deletionGuard); guardCond != nil {
+		extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, *guardCond)
+		extDNSWithStatus.Status.DeletionGuard = deletionGuard
+	} else {
+		extDNSWithStatus.Status.Conditions = removeConditions(extDNSWithStatus.Status.Conditions, ExternalDNSChangesBlockedConditionType)
+		extDNSWithStatus.Status.DeletionGuard = nil
+	}
+
+	// paused
+	if externalDNS.Spec.Paused {
+		extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, metav1.Condition{
+			Type:    ExternalDNSPausedConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "ReconciliationPaused",
+			Message: "The deployment is scaled down to zero replicas and the credentials request is not updated.",
+		})
+	} else {
+		extDNSWithStatus.Status.Conditions = removeConditions(extDNSWithStatus.Status.Conditions, ExternalDNSPausedConditionType)
+	}
+
+	// unsupported operand overrides
+	if overridesCond := computeUnsupportedOperandOverridesCondition(externalDNS); overridesCond != nil {
+		extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, *overridesCond)
+	} else {
+		extDNSWithStatus.Status.Conditions = removeConditions(extDNSWithStatus.Status.Conditions, ExternalDNSUnsupportedOperandOverridesConditionType)
+	}
 
 	extDNSWithStatus.Status.ObservedGeneration = extDNSWithStatus.Generation
 	extDNSWithStatus.Status.Zones = extDNSWithStatus.Spec.Zones
@@ -63,6 +140,152 @@ func (r *reconciler) updateExternalDNSStatus(ctx context.Context, externalDNS *o
 	return nil
 }
 
+// computeUnsupportedOperandOverridesCondition returns the condition which marks the instance
+// as using the unsupported configuration of the operand.
+// Returns nil if no overrides are set.
+func computeUnsupportedOperandOverridesCondition(externalDNS *operatorv1beta1.ExternalDNS) *metav1.Condition {
+	overrides := externalDNS.Spec.UnsupportedOperandOverrides
+	if overrides == nil || (len(overrides.Args) == 0 && len(overrides.Env) == 0) {
+		return nil
+	}
+	envNames := make([]string, 0, len(overrides.Env))
+	for _, env := range overrides.Env {
+		envNames = append(envNames, env.Name)
+	}
+	return &metav1.Condition{
+		Type:    ExternalDNSUnsupportedOperandOverridesConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "UnsupportedConfigurationInUse",
+		Message: fmt.Sprintf("The operand runs with the unsupported args [%s] and env [%s].", strings.Join(overrides.Args, " "), strings.Join(envNames, " ")),
+	}
+}
+
+// computeDeploymentConditions returns the externalDNS conditions based on the given deployment.
+func computeDeploymentConditions(ctx context.Context, cl client.Client, deployment *appsv1.Deployment) []metav1.Condition {
+	return []metav1.Condition{
+		computeDeploymentAvailableCondition(deployment),
+		computeMinReplicasCondition(deployment),
+		computeAllReplicasCondition(deployment),
+		computeDeploymentPodsScheduledCondition(ctx, cl, deployment),
+		computeDeploymentContainersReadyCondition(ctx, cl, deployment),
+	}
+}
+
+// pausedDeploymentConditions returns the pod- and replica-based conditions
+// for the paused instance whose deployments are scaled down to zero replicas.
+func pausedDeploymentConditions() []metav1.Condition {
+	return []metav1.Condition{
+		pausedCondition(ExternalDNSDeploymentAvailableConditionType),
+		pausedCondition(ExternalDNSDeploymentReplicasMinAvailableConditionType),
+		pausedCondition(ExternalDNSDeploymentReplicasAllAvailableConditionType),
+		pausedCondition(ExternalDNSPodsScheduledConditionType),
+		pausedCondition(ExternalDNSContainersReadyConditionType),
+	}
+}
+
+// pausedCondition returns the condition of the given type neutralised by the pause:
+// its status is unknown as no pods are run.
+func pausedCondition(condType string) metav1.Condition {
+	return metav1.Condition{
+		Type:    condType,
+		Status:  metav1.ConditionUnknown,
+		Reason:  "ReconciliationPaused",
+		Message: "The deployment is scaled down to zero replicas.",
+	}
+}
+
+// computeDeploymentsConditions returns the externalDNS conditions aggregated over the given deployments.
+// A condition is true only if it's true for all the deployments,
+// otherwise the condition of the first deployment for which it's not true is returned.
+func computeDeploymentsConditions(ctx context.Context, cl client.Client, deployments []*appsv1.Deployment) []metav1.Condition {
+	var aggregated []metav1.Condition
+	for _, deployment := range deployments {
+		conds := computeDeploymentConditions(ctx, cl, deployment)
+		if len(deployments) > 1 {
+			for i := range conds {
+				if conds[i].Status != metav1.ConditionTrue {
+					conds[i].Message = fmt.Sprintf("Deployment %s: %s", deployment.Name, conds[i].Message)
+				}
+			}
+		}
+		if aggregated == nil {
+			aggregated = conds
+			continue
+		}
+		for i := range conds {
+			if aggregated[i].Status == metav1.ConditionTrue && conds[i].Status != metav1.ConditionTrue {
+				aggregated[i] = conds[i]
+			}
+		}
+	}
+	return aggregated
+}
+
+// computeZoneDeploymentStatuses returns the statuses of the deployments of the zones
+// keeping the transition times of the conditions from the current statuses of the given externalDNS.
+// Returns nil unless each zone is served by its own deployment.
+func computeZoneDeploymentStatuses(ctx context.Context, cl client.Client, externalDNS *operatorv1beta1.ExternalDNS, deployments []*appsv1.Deployment) []operatorv1beta1.ExternalDNSZoneDeploymentStatus {
+	if externalDNS.Spec.DeploymentTopology != operatorv1beta1.DeploymentTopologyDeploymentPerZone {
+		return nil
+	}
+
+	current := map[string][]metav1.Condition{}
+	for _, status := range externalDNS.Status.ZoneDeployments {
+		current[status.DeploymentName] = status.Conditions
+	}
+
+	targets := operandContainerTargets(externalDNS)
+	statuses := []operatorv1beta1.ExternalDNSZoneDeploymentStatus{}
+	for _, deployment := range deployments {
+		// the deployment of the previous topology is not labeled with the container
+		target, found := targets[deployment.Labels[operandContainerLabel]]
+		if !found {
+			continue
+		}
+		conds := pausedDeploymentConditions()
+		if !externalDNS.Spec.Paused {
+			conds = computeDeploymentConditions(ctx, cl, deployment)
+		}
+		statuses = append(statuses, operatorv1beta1.ExternalDNSZoneDeploymentStatus{
+			Zone:           target.zone,
+			Provider:       target.provider,
+			DeploymentName: deployment.Name,
+			Conditions:     mergeConditions(current[deployment.Name], conds...),
+		})
+	}
+	return statuses
+}
+
+// containerTarget is the zone and the provider the operand container publishes to.
+type containerTarget struct {
+	zone     string
+	provider operatorv1beta1.ExternalDNSProviderType
+}
+
+// operandContainerTargets returns the targets of the operand containers of the given externalDNS
+// keyed by the container name.
+func operandContainerTargets(externalDNS *operatorv1beta1.ExternalDNS) map[string]containerTarget {
+	targets := map[string]containerTarget{}
+	for _, zone := range zonesOrAll(externalDNS.Spec.Zones) {
+		targets[controlleroperator.ExternalDNSContainerName(zone)] = containerTarget{zone: zone, provider: externalDNS.Spec.Provider.Type}
+	}
+	for _, target := range externalDNS.Spec.AdditionalProviders {
+		for _, zone := range zonesOrAll(target.Zones) {
+			targets[controlleroperator.ExternalDNSProviderContainerName(target.Type, zone)] = containerTarget{zone: zone, provider: target.Type}
+		}
+	}
+	return targets
+}
+
+// zonesOrAll returns the given zones or a single empty zone
+// which stands for all the zones of the provider.
+func zonesOrAll(zones []string) []string {
+	if len(zones) == 0 {
+		return []string{""}
+	}
+	return zones
+}
+
 // computeDeploymentAvailableCondition returns an externalDNS condition based on the deployment status & its conditions
 func computeDeploymentAvailableCondition(deployment *appsv1.Deployment) metav1.Condition {
 	for _, cond := range deployment.Status.Conditions {
@@ -249,6 +472,191 @@ func computeDeploymentPodsScheduledCondition(ctx context.Context, cl client.Clie
 
 }
 
+// computeDeploymentContainersReadyCondition lists the pods matching the namespace and the label selector of the deployment.
+// Returns condition true when all the started containers of the matching pods are ready.
+// Returns condition false if any container is not ready, e.g. because it fails the readiness probe
+// or is restarted by the liveness probe.
+func computeDeploymentContainersReadyCondition(ctx context.Context, cl client.Client, deployment *appsv1.Deployment) metav1.Condition {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return createContainersReadyUnknownCondition("InvalidLabelSelector", "Deployment has an invalid label selector.")
+	}
+	pods, err := getFilteredPodsList(ctx, cl, deployment.Namespace, selector)
+	if err != nil {
+		return createContainersReadyUnknownCondition("ContainersReadyUnknown", "Unable to list pods: "+err.Error())
+	}
+	if len(pods) == 0 {
+		return createContainersReadyUnknownCondition("NoLabelMatchingPods", fmt.Sprintf("No matching pods found for label selector: %v", deployment.Spec.Selector))
+	}
+
+	// Sort pods so that the result is deterministic.
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	message := "Some containers are not ready:"
+	notReady := false
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Ready {
+				continue
+			}
+			notReady = true
+			message += fmt.Sprintf(" Container %q of pod %q is not ready", status.Name, pod.Name)
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+				message += fmt.Sprintf(": %s", status.State.Waiting.Reason)
+			}
+			if status.RestartCount > 0 {
+				message += fmt.Sprintf(" (restarted %d times)", status.RestartCount)
+			}
+			message += "."
+		}
+	}
+	if notReady {
+		return metav1.Condition{
+			Type:    ExternalDNSContainersReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ContainersNotReady",
+			Message: message,
+		}
+	}
+	return metav1.Condition{
+		Type:    ExternalDNSContainersReadyConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AllContainersReady",
+		Message: "All containers are ready",
+	}
+}
+
+// computeProviderAvailableConditions returns a condition for each provider of the given externalDNS.
+// The conditions are computed only if the records are mirrored to additional providers.
+func (r *reconciler) computeProviderAvailableConditions(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, deployments []*appsv1.Deployment, secretExists bool) []metav1.Condition {
+	if len(externalDNS.Spec.AdditionalProviders) == 0 {
+		return nil
+	}
+
+	var pods []corev1.Pod
+	for _, deployment := range deployments {
+		if selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector); err == nil && !selector.Empty() {
+			// no pods are treated as no ready containers
+			deploymentPods, _ := getFilteredPodsList(ctx, r.client, deployment.Namespace, selector)
+			pods = append(pods, deploymentPods...)
+		}
+	}
+
+	conditions := []metav1.Condition{
+		computeProviderAvailableCondition(externalDNS.Spec.Provider.Type, secretExists || !operatorutils.CredentialsRequiredProvider(externalDNS), deployments, pods),
+	}
+	for _, target := range externalDNS.Spec.AdditionalProviders {
+		if !operatorutils.CredentialsRequiredProvider(operatorutils.ExternalDNSForProviderTarget(externalDNS, target)) {
+			conditions = append(conditions, computeProviderAvailableCondition(target.Type, true, deployments, pods))
+			continue
+		}
+		exists, _, err := r.currentExternalDNSSecret(ctx, controlleroperator.ExternalDNSDestProviderCredentialsSecretName(r.config.Namespace, externalDNS.Name, target.Type))
+		conditions = append(conditions, computeProviderAvailableCondition(target.Type, err == nil && exists, deployments, pods))
+	}
+	return conditions
+}
+
+// computeProviderAvailableCondition returns the condition of the given provider
+// based on its credentials secret and the readiness of its containers in the operand pods.
+func computeProviderAvailableCondition(providerType operatorv1beta1.ExternalDNSProviderType, secretExists bool, deployments []*appsv1.Deployment, pods []corev1.Pod) metav1.Condition {
+	cond := metav1.Condition{
+		Type: string(providerType) + ExternalDNSProviderAvailableConditionTypeSuffix,
+	}
+	if !secretExists {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "SecretNotFound"
+		cond.Message = "The credentials secret of the provider not found."
+		return cond
+	}
+	if len(deployments) == 0 {
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = "DeploymentNotFound"
+		cond.Message = "The operand deployment is not created yet."
+		return cond
+	}
+
+	notReady := []string{}
+	for _, name := range providerContainerNames(providerType, deployments) {
+		if !containerReady(name, pods) {
+			notReady = append(notReady, name)
+		}
+	}
+	if len(notReady) != 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "ContainersNotReady"
+		cond.Message = fmt.Sprintf("Some containers of the provider are not ready: %s", strings.Join(notReady, ", "))
+		return cond
+	}
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = "ContainersReady"
+	cond.Message = "All containers of the provider are ready."
+	return cond
+}
+
+// providerContainerNames returns the names of the deployments' containers which publish to the given provider.
+func providerContainerNames(providerType operatorv1beta1.ExternalDNSProviderType, deployments []*appsv1.Deployment) []string {
+	providerArgs := map[string]bool{
+		"--provider=" + providerStringTable[providerType]: true,
+	}
+	if providerType == operatorv1beta1.ProviderTypeAzure {
+		providerArgs["--provider="+externalDNSProviderTypeAzurePrivate] = true
+	}
+
+	names := []string{}
+	for _, deployment := range deployments {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			for _, arg := range container.Args {
+				if providerArgs[arg] {
+					names = append(names, container.Name)
+					break
+				}
+			}
+		}
+	}
+	return names
+}
+
+// containerReady returns true if the container with the given name is ready in any of the given pods.
+func containerReady(name string, pods []corev1.Pod) bool {
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == name && status.Ready {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// removeStaleProviderConditions removes the provider conditions which are not in the given list of the current ones.
+func removeStaleProviderConditions(conditions, current []metav1.Condition) []metav1.Condition {
+	currentTypes := map[string]bool{}
+	for _, cond := range current {
+		currentTypes[cond.Type] = true
+	}
+	filtered := []metav1.Condition{}
+	for _, cond := range conditions {
+		if strings.HasSuffix(cond.Type, ExternalDNSProviderAvailableConditionTypeSuffix) && !currentTypes[cond.Type] {
+			continue
+		}
+		filtered = append(filtered, cond)
+	}
+	return filtered
+}
+
+// removeConditions removes the conditions of the given type from the given list.
+func removeConditions(conditions []metav1.Condition, condType string) []metav1.Condition {
+	filtered := []metav1.Condition{}
+	for _, cond := range conditions {
+		if cond.Type != condType {
+			filtered = append(filtered, cond)
+		}
+	}
+	return filtered
+}
+
 // mergeConditions updates the conditions list with new conditions.
 // Each condition is added if no condition of the same type already exists.
 // Otherwise, the condition is merged with the existing condition of the same type.
@@ -285,6 +693,21 @@ func externalDNSStatusesEqual(a, b operatorv1beta1.ExternalDNSStatus) bool {
 	if !zonesEqual(a.Zones, b.Zones) {
 		return false
 	}
+	if a.MigratedFromOwnerID != b.MigratedFromOwnerID {
+		return false
+	}
+	if !cmp.Equal(a.DryRun, b.DryRun) {
+		return false
+	}
+	if !cmp.Equal(a.DeletionGuard, b.DeletionGuard) {
+		return false
+	}
+	if !zoneDeploymentsEqual(a.ZoneDeployments, b.ZoneDeployments) {
+		return false
+	}
+	if a.OperandStarted != b.OperandStarted {
+		return false
+	}
 	return conditionsEqual(a.Conditions, b.Conditions)
 }
 
@@ -295,6 +718,21 @@ func zonesEqual(a, b []string) bool {
 	}
 	return cmp.Equal(a, b, zoneCmpOpts...)
 }
+func zoneDeploymentsEqual(a, b []operatorv1beta1.ExternalDNSZoneDeploymentStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Zone != b[i].Zone || a[i].Provider != b[i].Provider || a[i].DeploymentName != b[i].DeploymentName {
+			return false
+		}
+		if !conditionsEqual(a[i].Conditions, b[i].Conditions) {
+			return false
+		}
+	}
+	return true
+}
+
 func conditionsEqual(a, b []metav1.Condition) bool {
 	conditionCmpOpts := []cmp.Option{
 		cmpopts.EquateEmpty(),
@@ -322,6 +760,15 @@ func createPodsScheduledUnknownCondition(reason, message string) metav1.Conditio
 	}
 }
 
+func createContainersReadyUnknownCondition(reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    ExternalDNSContainersReadyConditionType,
+		Status:  metav1.ConditionUnknown,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
 func createCredentialsSecretExistsCondition() metav1.Condition {
 	return metav1.Condition{
 		Type:    ExternalDNSCredentialsSecretExistsConditionType,
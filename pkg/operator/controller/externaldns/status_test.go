@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controlleroperator "github.com/openshift/external-dns-operator/pkg/operator/controller"
 	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
 )
 
@@ -303,6 +304,273 @@ func TestComputeDeploymentPodsScheduledCondition(t *testing.T) {
 	}
 }
 
+func TestComputeDeploymentContainersReadyCondition(t *testing.T) {
+	withContainerStatuses := func(pod corev1.Pod, statuses ...corev1.ContainerStatus) corev1.Pod {
+		pod.Status.ContainerStatuses = statuses
+		return pod
+	}
+	testCases := []struct {
+		name               string
+		existingDeployment appsv1.Deployment
+		existingPods       []corev1.Pod
+		expectedResult     metav1.Condition
+	}{
+		{
+			name:               "All containers are ready should return ConditionTrue",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods: []corev1.Pod{
+				withContainerStatuses(fakePod("pod", "external-dns-operator", "external-dns-operator", corev1.ConditionTrue, "Scheduled"),
+					corev1.ContainerStatus{Name: "external-dns-zone1", Ready: true},
+					corev1.ContainerStatus{Name: "external-dns-zone2", Ready: true},
+				),
+			},
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSContainersReadyConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "AllContainersReady",
+				Message: "All containers are ready",
+			},
+		},
+		{
+			name:               "Deployment selector empty or invalid should return ConditionUnknown",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, ""),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSContainersReadyConditionType,
+				Status:  metav1.ConditionUnknown,
+				Reason:  "InvalidLabelSelector",
+				Message: "Deployment has an invalid label selector.",
+			},
+		},
+		{
+			name:               "No pods matching deployment selector should return ConditionUnknown",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods: []corev1.Pod{
+				fakePod("pod", "external-dns-operator", "not-external-dns", corev1.ConditionTrue, "Scheduled"),
+			},
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSContainersReadyConditionType,
+				Status:  metav1.ConditionUnknown,
+				Reason:  "NoLabelMatchingPods",
+				Message: "No matching pods found for label selector: &LabelSelector{MatchLabels:map[string]string{name: external-dns-operator,},MatchExpressions:[]LabelSelectorRequirement{},}",
+			},
+		},
+		{
+			name:               "Containers failing the probes should return ConditionFalse",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods: []corev1.Pod{
+				withContainerStatuses(fakePod("pod", "external-dns-operator", "external-dns-operator", corev1.ConditionTrue, "Scheduled"),
+					corev1.ContainerStatus{Name: "external-dns-zone1", Ready: true},
+					corev1.ContainerStatus{Name: "external-dns-zone2", Ready: false},
+					corev1.ContainerStatus{
+						Name:         "external-dns-zone3",
+						Ready:        false,
+						RestartCount: 4,
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+						},
+					},
+				),
+			},
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSContainersReadyConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ContainersNotReady",
+				Message: `Some containers are not ready: Container "external-dns-zone2" of pod "pod" is not ready. Container "external-dns-zone3" of pod "pod" is not ready: CrashLoopBackOff (restarted 4 times).`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		fakeObjects := append(fakeRuntimeObjectFromPodList(tc.existingPods), &tc.existingDeployment)
+		cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(fakeObjects...).Build()
+		t.Run(tc.name, func(t *testing.T) {
+			cond := computeDeploymentContainersReadyCondition(context.TODO(), cl, &tc.existingDeployment)
+			if diff := cmp.Diff(tc.expectedResult, cond, ignoreTimeOpt); diff != "" {
+				t.Errorf("expected condition %v; got condition %v: \n %s", tc.expectedResult, cond, diff)
+			}
+		})
+	}
+}
+
+func TestComputeZoneDeploymentStatuses(t *testing.T) {
+	extDNS := testAWSExternalDNSZones([]string{test.PublicZone, test.PrivateZone}, operatorv1beta1.SourceTypeService)
+	extDNS.Spec.DeploymentTopology = operatorv1beta1.DeploymentTopologyDeploymentPerZone
+
+	zoneDeployment := func(zone string, isAvailable corev1.ConditionStatus, availableReplicas int32) *appsv1.Deployment {
+		containerName := controlleroperator.ExternalDNSContainerName(zone)
+		depl := fakeDeployment(appsv1.DeploymentAvailable, isAvailable, 1, "25%", "25%", availableReplicas, containerName)
+		depl.Name = controlleroperator.ExternalDNSZoneResourceName(test.OperandName, containerName)
+		depl.Labels = map[string]string{operandContainerLabel: containerName}
+		return &depl
+	}
+	publicDepl := zoneDeployment(test.PublicZone, corev1.ConditionTrue, 1)
+	privateDepl := zoneDeployment(test.PrivateZone, corev1.ConditionFalse, 0)
+	deployments := []*appsv1.Deployment{publicDepl, privateDepl}
+	cl := fake.NewClientBuilder().WithScheme(test.Scheme).Build()
+
+	// the aggregated condition reports the unavailable zone
+	conds := computeDeploymentsConditions(context.TODO(), cl, deployments)
+	available := findCondition(conds, ExternalDNSDeploymentAvailableConditionType)
+	if available == nil {
+		t.Fatalf("expected %s condition, got %v", ExternalDNSDeploymentAvailableConditionType, conds)
+	}
+	expectedAvailable := metav1.Condition{
+		Type:    ExternalDNSDeploymentAvailableConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  "DeploymentUnavailable",
+		Message: "Deployment " + privateDepl.Name + ": The deployment has Available status condition set to False (reason: Not really important for test) with message: Not really important for test",
+	}
+	if diff := cmp.Diff(expectedAvailable, *available, ignoreTimeOpt); diff != "" {
+		t.Errorf("unexpected aggregated condition (-want +got):\n%s", diff)
+	}
+
+	// each zone reports its own conditions
+	statuses := computeZoneDeploymentStatuses(context.TODO(), cl, extDNS, deployments)
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 zone deployment statuses, got %v", statuses)
+	}
+	expected := []struct {
+		zone      string
+		name      string
+		available metav1.ConditionStatus
+	}{
+		{zone: test.PublicZone, name: publicDepl.Name, available: metav1.ConditionTrue},
+		{zone: test.PrivateZone, name: privateDepl.Name, available: metav1.ConditionFalse},
+	}
+	for i, status := range statuses {
+		if status.Zone != expected[i].zone || status.Provider != operatorv1beta1.ProviderTypeAWS || status.DeploymentName != expected[i].name {
+			t.Errorf("unexpected zone deployment status: %+v", status)
+		}
+		cond := findCondition(status.Conditions, ExternalDNSDeploymentAvailableConditionType)
+		if cond == nil || cond.Status != expected[i].available {
+			t.Errorf("expected %s condition of zone %q to be %s, got %v", ExternalDNSDeploymentAvailableConditionType, status.Zone, expected[i].available, cond)
+		}
+	}
+
+	// no zone statuses for the single deployment
+	extDNS.Spec.DeploymentTopology = operatorv1beta1.DeploymentTopologySingleDeployment
+	if statuses := computeZoneDeploymentStatuses(context.TODO(), cl, extDNS, deployments); statuses != nil {
+		t.Errorf("expected no zone deployment statuses, got %v", statuses)
+	}
+}
+
+func TestComputeProviderAvailableCondition(t *testing.T) {
+	deployment := fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator")
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "external-dns-aws", Args: []string{"--provider=aws"}},
+		{Name: "external-dns-azure-public", Args: []string{"--provider=azure"}},
+		{Name: "external-dns-azure-private", Args: []string{"--provider=azure-private-dns"}},
+	}
+	pod := fakePod("external-dns-1", test.OperandNamespace, "external-dns-operator", corev1.ConditionTrue, "")
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "external-dns-aws", Ready: true},
+		{Name: "external-dns-azure-public", Ready: true},
+		{Name: "external-dns-azure-private", Ready: false},
+	}
+
+	testCases := []struct {
+		name               string
+		inputProviderType  operatorv1beta1.ExternalDNSProviderType
+		inputSecretExists  bool
+		existingDeployment *appsv1.Deployment
+		existingPods       []corev1.Pod
+		expectedResult     metav1.Condition
+	}{
+		{
+			name:               "Secret doesn't exist",
+			inputProviderType:  operatorv1beta1.ProviderTypeAWS,
+			existingDeployment: &deployment,
+			existingPods:       []corev1.Pod{pod},
+			expectedResult: metav1.Condition{
+				Type:    "AWSProviderAvailable",
+				Status:  metav1.ConditionFalse,
+				Reason:  "SecretNotFound",
+				Message: "The credentials secret of the provider not found.",
+			},
+		},
+		{
+			name:              "Deployment doesn't exist",
+			inputProviderType: operatorv1beta1.ProviderTypeAWS,
+			inputSecretExists: true,
+			expectedResult: metav1.Condition{
+				Type:    "AWSProviderAvailable",
+				Status:  metav1.ConditionUnknown,
+				Reason:  "DeploymentNotFound",
+				Message: "The operand deployment is not created yet.",
+			},
+		},
+		{
+			name:               "All containers are ready",
+			inputProviderType:  operatorv1beta1.ProviderTypeAWS,
+			inputSecretExists:  true,
+			existingDeployment: &deployment,
+			existingPods:       []corev1.Pod{pod},
+			expectedResult: metav1.Condition{
+				Type:    "AWSProviderAvailable",
+				Status:  metav1.ConditionTrue,
+				Reason:  "ContainersReady",
+				Message: "All containers of the provider are ready.",
+			},
+		},
+		{
+			name:               "Some containers are not ready",
+			inputProviderType:  operatorv1beta1.ProviderTypeAzure,
+			inputSecretExists:  true,
+			existingDeployment: &deployment,
+			existingPods:       []corev1.Pod{pod},
+			expectedResult: metav1.Condition{
+				Type:    "AzureProviderAvailable",
+				Status:  metav1.ConditionFalse,
+				Reason:  "ContainersNotReady",
+				Message: "Some containers of the provider are not ready: external-dns-azure-private",
+			},
+		},
+		{
+			name:               "No pods",
+			inputProviderType:  operatorv1beta1.ProviderTypeAWS,
+			inputSecretExists:  true,
+			existingDeployment: &deployment,
+			expectedResult: metav1.Condition{
+				Type:    "AWSProviderAvailable",
+				Status:  metav1.ConditionFalse,
+				Reason:  "ContainersNotReady",
+				Message: "Some containers of the provider are not ready: external-dns-aws",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var deployments []*appsv1.Deployment
+			if tc.existingDeployment != nil {
+				deployments = append(deployments, tc.existingDeployment)
+			}
+			cond := computeProviderAvailableCondition(tc.inputProviderType, tc.inputSecretExists, deployments, tc.existingPods)
+			if diff := cmp.Diff(tc.expectedResult, cond, ignoreTimeOpt); diff != "" {
+				t.Errorf("expected condition %v; got condition %v: \n %s", tc.expectedResult, cond, diff)
+			}
+		})
+	}
+}
+
+func TestRemoveStaleProviderConditions(t *testing.T) {
+	conditions := []metav1.Condition{
+		{Type: ExternalDNSDeploymentAvailableConditionType},
+		{Type: "AWSProviderAvailable"},
+		{Type: "AzureProviderAvailable"},
+	}
+	current := []metav1.Condition{
+		{Type: "AWSProviderAvailable"},
+	}
+	expected := []metav1.Condition{
+		{Type: ExternalDNSDeploymentAvailableConditionType},
+		{Type: "AWSProviderAvailable"},
+	}
+	if got := removeStaleProviderConditions(conditions, current); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected conditions %v, got %v", expected, got)
+	}
+}
+
 func TestMergeConditions(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -429,6 +697,10 @@ func TestGetPodsList(t *testing.T) {
 func TestUpdateExternalDNSStatus(t *testing.T) {
 	aDeployment := fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 8, "25%", "25%", 8, "external-dns-operator")
 	anExternalDNS := fakeExternalDNS()
+	anInMemoryExternalDNS := fakeInMemoryExternalDNS()
+	aPausedExternalDNS := fakePausedExternalDNS()
+	aScaledDownDeployment := fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionFalse, 0, "25%", "25%", 0, "no-pods")
+	anOverriddenExternalDNS := fakeOverriddenExternalDNS()
 	namespacedName := types.NamespacedName{
 		Namespace: "",
 		Name:      test.Name,
@@ -459,6 +731,39 @@ func TestUpdateExternalDNSStatus(t *testing.T) {
 			errExpected:     false,
 			expectedResult:  fakeExternalDNSWithStatusSecretMissing(),
 		},
+		{
+			name:            "Credentials secret not required",
+			existingObjects: append(fakeRuntimeObjectFromPodList(fakePodList()), anInMemoryExternalDNS),
+			existingExtDNS:  anInMemoryExternalDNS,
+			secretExists:    false,
+			errExpected:     false,
+			expectedResult:  fakeExternalDNSWithStatusSecretNotRequired(),
+		},
+		{
+			name:            "Paused",
+			existingObjects: append(fakeRuntimeObjectFromPodList(fakePodList()), aPausedExternalDNS),
+			existingExtDNS:  aPausedExternalDNS,
+			secretExists:    false,
+			errExpected:     false,
+			expectedResult:  fakeExternalDNSWithStatusPaused(),
+		},
+		{
+			name:               "Paused with scaled down deployment",
+			existingDeployment: &aScaledDownDeployment,
+			existingObjects:    append(fakeRuntimeObjectFromPodList(fakePodList()), &aScaledDownDeployment, aPausedExternalDNS),
+			existingExtDNS:     aPausedExternalDNS,
+			secretExists:       false,
+			errExpected:        false,
+			expectedResult:     fakeExternalDNSWithStatusPaused(),
+		},
+		{
+			name:            "Unsupported operand overrides",
+			existingObjects: append(fakeRuntimeObjectFromPodList(fakePodList()), anOverriddenExternalDNS),
+			existingExtDNS:  anOverriddenExternalDNS,
+			secretExists:    false,
+			errExpected:     false,
+			expectedResult:  fakeExternalDNSWithStatusOverridden(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -470,7 +775,11 @@ func TestUpdateExternalDNSStatus(t *testing.T) {
 			log:    zap.New(zap.UseDevMode(true)),
 		}
 
-		err := r.updateExternalDNSStatus(context.TODO(), tc.existingExtDNS, tc.existingDeployment, tc.secretExists)
+		var deployments []*appsv1.Deployment
+		if tc.existingDeployment != nil {
+			deployments = append(deployments, tc.existingDeployment)
+		}
+		err := r.updateExternalDNSStatus(context.TODO(), tc.existingExtDNS, deployments, tc.secretExists, nil)
 		if tc.errExpected && err == nil {
 			t.Error("expected an error but got none")
 		} else if !tc.errExpected {
@@ -611,6 +920,12 @@ func fakeExternalDNSWithStatus() operatorv1beta1.ExternalDNS {
 		Reason:  "AllPodsScheduled",
 		Message: "All pods are scheduled",
 	}
+	condContainersReady := metav1.Condition{
+		Type:    ExternalDNSContainersReadyConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AllContainersReady",
+		Message: "All containers are ready",
+	}
 	condSecretExists := metav1.Condition{
 		Type:    ExternalDNSCredentialsSecretExistsConditionType,
 		Status:  metav1.ConditionTrue,
@@ -621,6 +936,7 @@ func fakeExternalDNSWithStatus() operatorv1beta1.ExternalDNS {
 	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condAllReplicaAvailable)
 	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condMinReplicaAvailable)
 	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condPodScheduled)
+	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condContainersReady)
 	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condSecretExists)
 
 	return *extDNS
@@ -638,6 +954,80 @@ func fakeExternalDNSWithStatusSecretMissing() operatorv1beta1.ExternalDNS {
 	return *extDNS
 }
 
+func fakePausedExternalDNS() *operatorv1beta1.ExternalDNS {
+	extDNS := fakeExternalDNS()
+	extDNS.Spec.Paused = true
+	return extDNS
+}
+
+func fakeExternalDNSWithStatusPaused() operatorv1beta1.ExternalDNS {
+	extDNS := fakeExternalDNSWithStatusSecretMissing()
+	extDNS.Status.Conditions = append(extDNS.Status.Conditions, metav1.Condition{
+		Type:    ExternalDNSPausedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ReconciliationPaused",
+		Message: "The deployment is scaled down to zero replicas and the credentials request is not updated.",
+	})
+	// the pod- and replica-based conditions don't report the missing pods
+	for _, condType := range []string{
+		ExternalDNSDeploymentAvailableConditionType,
+		ExternalDNSDeploymentReplicasMinAvailableConditionType,
+		ExternalDNSDeploymentReplicasAllAvailableConditionType,
+		ExternalDNSPodsScheduledConditionType,
+		ExternalDNSContainersReadyConditionType,
+	} {
+		extDNS.Status.Conditions = append(extDNS.Status.Conditions, metav1.Condition{
+			Type:    condType,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "ReconciliationPaused",
+			Message: "The deployment is scaled down to zero replicas.",
+		})
+	}
+
+	return extDNS
+}
+
+func fakeOverriddenExternalDNS() *operatorv1beta1.ExternalDNS {
+	extDNS := fakeExternalDNS()
+	extDNS.Spec.UnsupportedOperandOverrides = &operatorv1beta1.ExternalDNSUnsupportedOperandOverrides{
+		Args: []string{"--aws-batch-change-size=100"},
+		Env:  []corev1.EnvVar{{Name: "AWS_MAX_ATTEMPTS", Value: "5"}},
+	}
+	return extDNS
+}
+
+func fakeExternalDNSWithStatusOverridden() operatorv1beta1.ExternalDNS {
+	extDNS := fakeExternalDNSWithStatusSecretMissing()
+	extDNS.Status.Conditions = append(extDNS.Status.Conditions, metav1.Condition{
+		Type:    ExternalDNSUnsupportedOperandOverridesConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "UnsupportedConfigurationInUse",
+		Message: "The operand runs with the unsupported args [--aws-batch-change-size=100] and env [AWS_MAX_ATTEMPTS].",
+	})
+
+	return extDNS
+}
+
+func fakeInMemoryExternalDNS() *operatorv1beta1.ExternalDNS {
+	extDNS := fakeExternalDNS()
+	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeInMemory,
+	}
+	return extDNS
+}
+
+func fakeExternalDNSWithStatusSecretNotRequired() operatorv1beta1.ExternalDNS {
+	extDNS := fakeInMemoryExternalDNS()
+	extDNS.Status.Conditions = append(extDNS.Status.Conditions, metav1.Condition{
+		Type:    ExternalDNSCredentialsSecretExistsConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "SecretNotRequired",
+		Message: "The provider doesn't require the credentials secret.",
+	})
+
+	return *extDNS
+}
+
 func fakePod(name string, namespace string, selectorLabel string, status corev1.ConditionStatus, reason string) corev1.Pod {
 	return corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
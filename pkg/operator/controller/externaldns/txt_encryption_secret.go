@@ -0,0 +1,144 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	operatorutils "github.com/openshift/external-dns-operator/pkg/utils"
+)
+
+// ensureExternalDNSTXTEncryptionSecret ensures that the TXT registry's encryption key secret
+// referenced by the given externalDNS is copied from the operator namespace into the operand namespace.
+// Returns a Boolean value indicating whether the source secret exists, a pointer to the destination secret, and an error when relevant.
+func (r *reconciler) ensureExternalDNSTXTEncryptionSecret(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) (bool, *corev1.Secret, error) {
+	sourceName := types.NamespacedName{
+		Namespace: r.config.OperatorNamespace,
+		Name:      controller.ExternalDNSTXTEncryptionSecretNameFromRegistry(externalDNS),
+	}
+	destName := controller.ExternalDNSDestTXTEncryptionSecretName(r.config.Namespace, externalDNS.Name)
+
+	sourceExists, source, err := r.currentExternalDNSSecret(ctx, sourceName)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get the source TXT encryption secret %s: %w", sourceName, err)
+	} else if !sourceExists {
+		return false, nil, nil
+	}
+
+	desired, err := desiredExternalDNSTXTEncryptionSecret(source, destName)
+	if err != nil {
+		return false, nil, err
+	}
+	operatorutils.ApplyOperandMetadata(externalDNS, &desired.ObjectMeta)
+
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return false, nil, fmt.Errorf("failed to set the controller reference for TXT encryption secret: %w", err)
+	}
+
+	exist, current, err := r.currentExternalDNSSecret(ctx, destName)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get the target TXT encryption secret %s: %w", destName, err)
+	}
+
+	if !exist {
+		if err := r.createExternalDNSSecret(ctx, desired); err != nil {
+			return false, nil, err
+		}
+		_, current, err := r.currentExternalDNSSecret(ctx, destName)
+		return true, current, err
+	}
+
+	if updated, err := r.updateExternalDNSSecret(ctx, current, desired); err != nil {
+		return true, current, err
+	} else if updated {
+		_, current, err := r.currentExternalDNSSecret(ctx, destName)
+		return true, current, err
+	}
+
+	return true, current, nil
+}
+
+// desiredExternalDNSTXTEncryptionSecret returns the desired destination secret with the AES key from the source one.
+// Returns an error if the source secret doesn't have a valid AES key.
+func desiredExternalDNSTXTEncryptionSecret(source *corev1.Secret, destName types.NamespacedName) (*corev1.Secret, error) {
+	key, found := source.Data[txtEncryptAESKeyKey]
+	if !found {
+		return nil, fmt.Errorf("TXT encryption secret %s/%s doesn't contain %q key", source.Namespace, source.Name, txtEncryptAESKeyKey)
+	}
+	// the operand fails to start with a key of another length
+	if !validTXTEncryptAESKey(key) {
+		return nil, fmt.Errorf("TXT encryption secret %s/%s has %q key of %d bytes, expected %d bytes or their base64 encoding", source.Namespace, source.Name, txtEncryptAESKeyKey, len(key), txtEncryptAESKeyLength)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: destName.Namespace,
+			Name:      destName.Name,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			txtEncryptAESKeyKey: key,
+		},
+	}, nil
+}
+
+// validTXTEncryptAESKey returns true if the given AES key is accepted by the operand:
+// either the raw key of the expected length or its standard base64 encoding.
+func validTXTEncryptAESKey(key []byte) bool {
+	if len(key) == txtEncryptAESKeyLength {
+		return true
+	}
+	decoded, err := base64.StdEncoding.DecodeString(string(key))
+	return err == nil && len(decoded) == txtEncryptAESKeyLength
+}
+
+// createExternalDNSSecret creates the given secret using the reconciler's client.
+func (r *reconciler) createExternalDNSSecret(ctx context.Context, secret *corev1.Secret) error {
+	if err := r.client.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create externalDNS secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+
+	r.log.Info("created externalDNS secret", "namespace", secret.Namespace, "name", secret.Name)
+	return nil
+}
+
+// updateExternalDNSSecret updates the data and the custom metadata of the current secret if they differ from the desired ones.
+// Returns a boolean if an update was made, and an error when relevant.
+func (r *reconciler) updateExternalDNSSecret(ctx context.Context, current, desired *corev1.Secret) (bool, error) {
+	updated := current.DeepCopy()
+	metadataChanged := operatorutils.OperandMetadataChanged(&current.ObjectMeta, &desired.ObjectMeta, &updated.ObjectMeta)
+	if !metadataChanged && reflect.DeepEqual(current.Data, desired.Data) {
+		return false, nil
+	}
+
+	updated.Data = desired.Data
+	if err := r.client.Update(ctx, updated); err != nil {
+		return false, fmt.Errorf("failed to update externalDNS secret %s/%s: %w", updated.Namespace, updated.Name, err)
+	}
+
+	r.log.Info("updated externalDNS secret", "namespace", updated.Namespace, "name", updated.Name)
+	return true, nil
+}
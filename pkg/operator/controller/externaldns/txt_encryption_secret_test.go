@@ -0,0 +1,264 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestEnsureExternalDNSTXTEncryptionSecret(t *testing.T) {
+	extDNS := testAWSExternalDNSWithTXTEncryption(operatorv1beta1.SourceTypeService)
+	destName := "external-dns-txt-encryption-test"
+	ownerRefs := []metav1.OwnerReference{
+		{
+			APIVersion:         operatorv1beta1.GroupVersion.String(),
+			Kind:               "ExternalDNS",
+			Name:               extDNS.Name,
+			Controller:         &test.TrueVar,
+			BlockOwnerDeletion: &test.TrueVar,
+		},
+	}
+	// 32 bytes long AES key
+	newKey := []byte("0123456789abcdef0123456789abcdef")
+	sourceSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "txt-encryption-key",
+			Namespace: test.OperatorNamespace,
+		},
+		Data: map[string][]byte{
+			"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": newKey,
+			"unrelated":                        []byte("value"),
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		operandMetadata *operatorv1beta1.ExternalDNSOperandMetadata
+		existingObjects []runtime.Object
+		expectedExist   bool
+		expectedSecret  *corev1.Secret
+		errExpected     bool
+	}{
+		{
+			name:            "Source doesn't exist",
+			existingObjects: []runtime.Object{},
+			expectedExist:   false,
+		},
+		{
+			name: "Source doesn't have the key",
+			existingObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "txt-encryption-key",
+						Namespace: test.OperatorNamespace,
+					},
+					Data: map[string][]byte{
+						"unrelated": []byte("value"),
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Source key isn't 32 bytes long",
+			existingObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "txt-encryption-key",
+						Namespace: test.OperatorNamespace,
+					},
+					Data: map[string][]byte{
+						"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": []byte("short-key"),
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Source key doesn't decode to 32 bytes",
+			existingObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "txt-encryption-key",
+						Namespace: test.OperatorNamespace,
+					},
+					Data: map[string][]byte{
+						"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": []byte(base64.StdEncoding.EncodeToString(newKey[:16])),
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Source key is base64 encoded",
+			existingObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "txt-encryption-key",
+						Namespace: test.OperatorNamespace,
+					},
+					Data: map[string][]byte{
+						"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": []byte(base64.StdEncoding.EncodeToString(newKey)),
+					},
+				},
+			},
+			expectedExist: true,
+			expectedSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            destName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				Type: corev1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": []byte(base64.StdEncoding.EncodeToString(newKey)),
+				},
+			},
+		},
+		{
+			name:            "Destination doesn't exist",
+			existingObjects: []runtime.Object{sourceSecret},
+			expectedExist:   true,
+			expectedSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            destName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				Type: corev1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": newKey,
+				},
+			},
+		},
+		{
+			name: "Destination is outdated",
+			existingObjects: []runtime.Object{
+				sourceSecret,
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            destName,
+						Namespace:       test.OperandNamespace,
+						OwnerReferences: ownerRefs,
+					},
+					Type: corev1.SecretTypeOpaque,
+					Data: map[string][]byte{
+						"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": []byte("old-key"),
+					},
+				},
+			},
+			expectedExist: true,
+			expectedSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            destName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+				},
+				Type: corev1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": newKey,
+				},
+			},
+		},
+		{
+			name: "Destination misses the custom operand metadata",
+			operandMetadata: &operatorv1beta1.ExternalDNSOperandMetadata{
+				Labels: map[string]string{"cost-center": "dns"},
+			},
+			existingObjects: []runtime.Object{
+				sourceSecret,
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            destName,
+						Namespace:       test.OperandNamespace,
+						OwnerReferences: ownerRefs,
+					},
+					Type: corev1.SecretTypeOpaque,
+					Data: map[string][]byte{
+						"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": newKey,
+					},
+				},
+			},
+			expectedExist: true,
+			expectedSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            destName,
+					Namespace:       test.OperandNamespace,
+					OwnerReferences: ownerRefs,
+					Labels:          map[string]string{"cost-center": "dns"},
+					Annotations: map[string]string{
+						"externaldns.olm.openshift.io/operand-labels": "cost-center",
+					},
+				},
+				Type: corev1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					"EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY": newKey,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithRuntimeObjects(tc.existingObjects...).Build()
+			r := &reconciler{
+				config: Config{
+					Namespace:         test.OperandNamespace,
+					OperatorNamespace: test.OperatorNamespace,
+				},
+				client: cl,
+				scheme: test.Scheme,
+				log:    zap.New(zap.UseDevMode(true)),
+			}
+			extDNS := extDNS.DeepCopy()
+			extDNS.Spec.OperandMetadata = tc.operandMetadata
+			gotExist, gotSecret, err := r.ensureExternalDNSTXTEncryptionSecret(context.TODO(), extDNS)
+			if err != nil {
+				if !tc.errExpected {
+					t.Fatalf("unexpected error received: %v", err)
+				}
+				return
+			} else if tc.errExpected {
+				t.Fatalf("error expected but not received")
+			}
+			if gotExist != tc.expectedExist {
+				t.Errorf("expected source secret's exist to be %t, got %t", tc.expectedExist, gotExist)
+			}
+			if tc.expectedSecret == nil {
+				return
+			}
+			diffOpts := cmpopts.IgnoreFields(corev1.Secret{}, "ResourceVersion", "Kind", "APIVersion")
+			if diff := cmp.Diff(*tc.expectedSecret, *gotSecret, diffOpts); diff != "" {
+				t.Errorf("unexpected secret (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -57,6 +57,12 @@ func ExternalDNSResourceName(externalDNS *operatorv1beta1.ExternalDNS) string {
 	return ExternalDNSBaseName + "-" + externalDNS.Name
 }
 
+// ExternalDNSZoneResourceName returns the name for the resources unique for the given container
+// of the ExternalDNS instance with the given resource name.
+func ExternalDNSZoneResourceName(resourceName, containerName string) string {
+	return resourceName + "-" + strings.TrimPrefix(containerName, ExternalDNSBaseName+"-")
+}
+
 // ExternalDNSGlobalResourceName returns the name for the resources shared among ExternalDNS instances.
 func ExternalDNSGlobalResourceName() string {
 	return ExternalDNSBaseName
@@ -67,6 +73,11 @@ func ExternalDNSContainerName(zone string) string {
 	return ExternalDNSBaseName + "-" + hashString(zone)
 }
 
+// ExternalDNSProviderContainerName returns the container name unique for the given DNS zone of the given additional provider.
+func ExternalDNSProviderContainerName(providerType operatorv1beta1.ExternalDNSProviderType, zone string) string {
+	return ExternalDNSBaseName + "-" + hashString(strings.ToLower(string(providerType))+"/"+zone)
+}
+
 // ExternalDNSDestCredentialsSecretName returns the namespaced name of the destination (operand) credentials secret
 func ExternalDNSDestCredentialsSecretName(operandNamespace, extdnsName string) types.NamespacedName {
 	return types.NamespacedName{
@@ -75,6 +86,15 @@ func ExternalDNSDestCredentialsSecretName(operandNamespace, extdnsName string) t
 	}
 }
 
+// ExternalDNSDestProviderCredentialsSecretName returns the namespaced name of the destination (operand) credentials secret
+// of the given additional provider
+func ExternalDNSDestProviderCredentialsSecretName(operandNamespace, extdnsName string, providerType operatorv1beta1.ExternalDNSProviderType) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: operandNamespace,
+		Name:      ExternalDNSBaseName + "-credentials-" + extdnsName + "-" + strings.ToLower(string(providerType)),
+	}
+}
+
 // ExternalDNSDestTrustedCAConfigMapName returns the namespaced name of the destination (operand) trusted CA configmap
 func ExternalDNSDestTrustedCAConfigMapName(operandNamespace string) types.NamespacedName {
 	return types.NamespacedName{
@@ -83,6 +103,58 @@ func ExternalDNSDestTrustedCAConfigMapName(operandNamespace string) types.Namesp
 	}
 }
 
+// ExternalDNSDestInfobloxCAConfigMapName returns the namespaced name of the destination (operand) configmap
+// with the CA bundle of the Infoblox grid
+func ExternalDNSDestInfobloxCAConfigMapName(operandNamespace, extdnsName string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: operandNamespace,
+		Name:      ExternalDNSBaseName + "-infoblox-ca-" + extdnsName,
+	}
+}
+
+// ExternalDNSInfobloxCAConfigMapNameFromProvider returns the name of the Infoblox grid CA configmap retrieved from externalDNS resource
+func ExternalDNSInfobloxCAConfigMapNameFromProvider(externalDNS *operatorv1beta1.ExternalDNS) string {
+	if externalDNS.Spec.Provider.Type == operatorv1beta1.ProviderTypeInfoblox && externalDNS.Spec.Provider.Infoblox != nil && externalDNS.Spec.Provider.Infoblox.GridCA != nil {
+		return externalDNS.Spec.Provider.Infoblox.GridCA.Name
+	}
+	return ""
+}
+
+// ExternalDNSDestTXTEncryptionSecretName returns the namespaced name of the destination (operand) secret
+// with the AES key of the TXT registry
+func ExternalDNSDestTXTEncryptionSecretName(operandNamespace, extdnsName string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: operandNamespace,
+		Name:      ExternalDNSBaseName + "-txt-encryption-" + extdnsName,
+	}
+}
+
+// ExternalDNSTXTEncryptionSecretNameFromRegistry returns the name of the TXT registry's encryption key secret retrieved from externalDNS resource
+func ExternalDNSTXTEncryptionSecretNameFromRegistry(externalDNS *operatorv1beta1.ExternalDNS) string {
+	if externalDNS.Spec.Registry != nil && externalDNS.Spec.Registry.Encryption != nil {
+		return externalDNS.Spec.Registry.Encryption.Key.Name
+	}
+	return ""
+}
+
+// ExternalDNSDestDryRunConfigMapName returns the namespaced name of the destination (operand) configmap
+// with the changes planned in the dry run mode
+func ExternalDNSDestDryRunConfigMapName(operandNamespace, extdnsName string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: operandNamespace,
+		Name:      ExternalDNSBaseName + "-dry-run-" + extdnsName,
+	}
+}
+
+// ExternalDNSDestRecordCleanupJobName returns the namespaced name of the destination (operand) job
+// which deletes the DNS records owned by the deleted ExternalDNS
+func ExternalDNSDestRecordCleanupJobName(operandNamespace, extdnsName string) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: operandNamespace,
+		Name:      ExternalDNSBaseName + "-cleanup-" + extdnsName,
+	}
+}
+
 func ExternalDNSCredentialsSourceNamespace(cfg *operatorconfig.Config) string {
 	// TODO: use openshift-config namespace for OpenShift?
 	return cfg.OperatorNamespace
@@ -105,6 +177,9 @@ func ExternalDNSCredentialsSecretNameFromProvider(externalDNS *operatorv1beta1.E
 		}
 	case operatorv1beta1.ProviderTypeBlueCat:
 		if externalDNS.Spec.Provider.BlueCat != nil {
+			if externalDNS.Spec.Provider.BlueCat.Credentials != nil {
+				return externalDNS.Spec.Provider.BlueCat.Credentials.Name
+			}
 			return externalDNS.Spec.Provider.BlueCat.ConfigFile.Name
 		}
 	case operatorv1beta1.ProviderTypeInfoblox:
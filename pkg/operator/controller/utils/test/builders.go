@@ -75,6 +75,16 @@ func (b *ExternalDNSBuilder) WithZones(ids ...string) *ExternalDNSBuilder {
 	return b
 }
 
+func (b *ExternalDNSBuilder) WithRegistry(registry *operatorv1beta1.ExternalDNSRegistry) *ExternalDNSBuilder {
+	b.extDNS.Spec.Registry = registry
+	return b
+}
+
+func (b *ExternalDNSBuilder) WithPaused() *ExternalDNSBuilder {
+	b.extDNS.Spec.Paused = true
+	return b
+}
+
 func (b *ExternalDNSBuilder) Build() *operatorv1beta1.ExternalDNS {
 	return b.extDNS
 }
@@ -46,13 +46,20 @@ type Operator struct {
 // +kubebuilder:rbac:groups=externaldns.olm.openshift.io,resources=externaldnses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=externaldns.olm.openshift.io,resources=externaldnses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=externaldns.olm.openshift.io,resources=externaldnses/finalizers,verbs=update
+// +kubebuilder:rbac:groups=externaldns.olm.openshift.io,resources=dnschangerequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=externaldns.olm.openshift.io,resources=dnschangerequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cloudcredential.openshift.io,resources=credentialsrequests;credentialsrequests/status;credentialsrequests/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;watch;list
 // +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;patch,resourceNames=external-dns-operator
 // local role
-// +kubebuilder:rbac:groups="",namespace=external-dns-operator,resources=secrets;serviceaccounts;configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",namespace=external-dns-operator,resources=secrets;serviceaccounts;configmaps;services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="monitoring.coreos.com",namespace=external-dns-operator,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="apps",namespace=external-dns-operator,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="batch",namespace=external-dns-operator,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",namespace=external-dns-operator,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=external-dns-operator,resources=pods/log,verbs=get
 
 // New creates a new operator from cliCfg and opCfg.
 func New(cliCfg *rest.Config, opCfg *operatorconfig.Config) (*Operator, error) {
@@ -120,6 +127,7 @@ func New(cliCfg *rest.Config, opCfg *operatorconfig.Config) (*Operator, error) {
 	if _, err := externaldnsctrl.New(mgr, externaldnsctrl.Config{
 		Namespace:         opCfg.OperandNamespace,
 		Image:             opCfg.ExternalDNSImage,
+		ProxyImage:        opCfg.KubeRBACProxyImage,
 		OperatorNamespace: opCfg.OperatorNamespace,
 		IsOpenShift:       opCfg.IsOpenShift,
 		PlatformStatus:    opCfg.PlatformStatus,
@@ -0,0 +1,138 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+)
+
+const (
+	// operandLabelsAnnotation lists the keys of the custom labels set by the operator on the operand resource.
+	operandLabelsAnnotation = "externaldns.olm.openshift.io/operand-labels"
+	// operandAnnotationsAnnotation lists the keys of the custom annotations set by the operator on the operand resource.
+	operandAnnotationsAnnotation = "externaldns.olm.openshift.io/operand-annotations"
+)
+
+// ApplyOperandMetadata adds the custom labels and annotations of the given ExternalDNS to the given object metadata.
+// The keys of the custom labels and annotations are recorded in the annotations of the object
+// to be able to remove them once they are removed from the ExternalDNS.
+func ApplyOperandMetadata(e *operatorv1beta1.ExternalDNS, meta *metav1.ObjectMeta) {
+	custom := e.Spec.OperandMetadata
+	if custom == nil {
+		return
+	}
+	if len(custom.Labels) > 0 {
+		if meta.Labels == nil {
+			meta.Labels = map[string]string{}
+		}
+		for key, value := range custom.Labels {
+			meta.Labels[key] = value
+		}
+	}
+	if len(custom.Annotations) > 0 || len(custom.Labels) > 0 {
+		if meta.Annotations == nil {
+			meta.Annotations = map[string]string{}
+		}
+		for key, value := range custom.Annotations {
+			meta.Annotations[key] = value
+		}
+	}
+	if len(custom.Labels) > 0 {
+		meta.Annotations[operandLabelsAnnotation] = joinKeys(custom.Labels)
+	}
+	if len(custom.Annotations) > 0 {
+		meta.Annotations[operandAnnotationsAnnotation] = joinKeys(custom.Annotations)
+	}
+}
+
+// OperandMetadataChanged checks that the current object metadata has the expected labels and annotations.
+// The labels and annotations set by others are kept,
+// the custom ones previously set by the operator and no longer expected are removed.
+// Returns a Boolean value indicating whether the updated object metadata differs from the current one.
+func OperandMetadataChanged(current, expected, updated *metav1.ObjectMeta) bool {
+	labelsChanged := OperandLabelsChanged(current, expected, updated)
+	annotationsChanged := OperandAnnotationsChanged(current, expected, updated, nil)
+	return labelsChanged || annotationsChanged
+}
+
+// OperandLabelsChanged is like OperandMetadataChanged but for the labels only.
+func OperandLabelsChanged(current, expected, updated *metav1.ObjectMeta) bool {
+	managed := splitKeys(current.Annotations[operandLabelsAnnotation])
+	return metadataChanged(current.Labels, expected.Labels, &updated.Labels, func(key string) bool {
+		return managed[key]
+	})
+}
+
+// OperandAnnotationsChanged is like OperandMetadataChanged but for the annotations only.
+// The annotations for which the given function returns true are removed as well if they are not expected.
+func OperandAnnotationsChanged(current, expected, updated *metav1.ObjectMeta, isManaged func(key string) bool) bool {
+	managed := splitKeys(current.Annotations[operandAnnotationsAnnotation])
+	managed[operandLabelsAnnotation] = true
+	managed[operandAnnotationsAnnotation] = true
+	return metadataChanged(current.Annotations, expected.Annotations, &updated.Annotations, func(key string) bool {
+		return managed[key] || (isManaged != nil && isManaged(key))
+	})
+}
+
+// metadataChanged updates the given labels or annotations with the expected values
+// and removes the unexpected ones for which the given function returns true.
+// Returns true if the updated metadata differs from the current one.
+func metadataChanged(current, expected map[string]string, updated *map[string]string, isManaged func(key string) bool) bool {
+	changed := false
+	for key, value := range expected {
+		if currentValue, found := current[key]; !found || currentValue != value {
+			if *updated == nil {
+				*updated = map[string]string{}
+			}
+			(*updated)[key] = value
+			changed = true
+		}
+	}
+	for key := range current {
+		if _, found := expected[key]; !found && isManaged(key) {
+			delete(*updated, key)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// joinKeys returns the sorted keys of the given map joined with commas.
+func joinKeys(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// splitKeys returns the set of the keys joined with commas.
+func splitKeys(joined string) map[string]bool {
+	keys := map[string]bool{}
+	for _, key := range strings.Split(joined, ",") {
+		if key != "" {
+			keys[key] = true
+		}
+	}
+	return keys
+}
@@ -31,6 +31,22 @@ func ManagedCredentialsProvider(e *operatorv1beta1.ExternalDNS) bool {
 	return false
 }
 
+// ExternalDNSForProviderTarget returns a copy of the given ExternalDNS
+// with the provider and the zones replaced by the ones from the given provider target.
+// The copy can be used to build the resources specific to the provider target.
+func ExternalDNSForProviderTarget(e *operatorv1beta1.ExternalDNS, target operatorv1beta1.ExternalDNSProviderTarget) *operatorv1beta1.ExternalDNS {
+	targetExtDNS := e.DeepCopy()
+	targetExtDNS.Spec.Provider = *target.ExternalDNSProvider.DeepCopy()
+	targetExtDNS.Spec.Zones = target.Zones
+	targetExtDNS.Spec.AdditionalProviders = nil
+	return targetExtDNS
+}
+
+// CredentialsRequiredProvider returns true if the ExternalDNS provider needs the credentials to be run
+func CredentialsRequiredProvider(e *operatorv1beta1.ExternalDNS) bool {
+	return e.Spec.Provider.Type != operatorv1beta1.ProviderTypeInMemory
+}
+
 // EnvProxySupportedProvider returns true if the ExternalDNS provider supports the proxy settings via environment variables HTTP(S)_PROXY, NO_PROXY
 func EnvProxySupportedProvider(e *operatorv1beta1.ExternalDNS) bool {
 	switch e.Spec.Provider.Type {